@@ -0,0 +1,13 @@
+# Get a short-lived access token for the Funnel API without storing it in the state
+ephemeral "funnel_access_token" "current" {}
+
+# Trigger an export run from a script with the token
+resource "terraform_data" "run_export" {
+  provisioner "local-exec" {
+    command = "./scripts/run-export.sh"
+    environment = {
+      FUNNEL_SUBSCRIPTION_ID = ephemeral.funnel_access_token.current.subscription_id
+      FUNNEL_ACCESS_TOKEN    = ephemeral.funnel_access_token.current.access_token
+    }
+  }
+}
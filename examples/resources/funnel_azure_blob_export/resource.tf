@@ -0,0 +1,58 @@
+# Fetch export fields
+data "funnel_export_field" "date" {
+  workspace = var.workspace_id
+  id        = "date"
+}
+
+data "funnel_export_field" "campaign_name" {
+  workspace = var.workspace_id
+  id        = "campaign_name"
+}
+
+data "funnel_export_field" "cost" {
+  workspace = var.workspace_id
+  id        = "cost"
+}
+
+# Azure Blob Storage export authenticated with a service principal
+resource "funnel_azure_blob_export" "basic" {
+  workspace = var.workspace_id
+  name      = "Daily Marketing Data to Azure Blob Storage"
+  enabled   = true
+  schedule  = "0 2 * * *" # Daily at 2 AM
+
+  destination {
+    storage_account    = "funnelexports"
+    container          = "marketing"
+    path_prefix        = "marketing-data"
+    output_id_template = "funnel_export_{date}"
+    tenant_id          = var.azure_tenant_id
+    client_id          = var.azure_client_id
+
+    # Never stored in the plan or state. Bump the version to send a rotated secret.
+    client_secret_wo       = var.azure_client_secret
+    credentials_wo_version = 1
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.campaign_name,
+    data.funnel_export_field.cost
+  ]
+
+  format {
+    type    = "csv"
+    metrics = "export"
+  }
+
+  range {
+    rolling_start {
+      period  = "days"
+      periods = -7
+    }
+    rolling_end {
+      period  = "days"
+      periods = -1
+    }
+  }
+}
@@ -55,3 +55,52 @@ resource "funnel_bigquery_export" "basic" {
     }
   }
 }
+
+# Weekly BigQuery export with a structured schedule in local time
+resource "funnel_bigquery_export" "weekly" {
+  workspace = var.workspace_id
+  name      = "Weekly Marketing Data to BigQuery"
+  enabled   = true
+
+  schedule_config {
+    frequency = "weekly"
+    weekday   = "monday"
+    hour      = 6
+    minute    = 30
+    timezone  = "Asia/Tokyo"
+  }
+
+  destination {
+    project_id         = "my-gcp-project"
+    dataset_id         = "funnel_marketing_data"
+    output_id_template = "weekly_export_{date}"
+
+    # Append each run to a single table partitioned by month and clustered by campaign
+    write_mode        = "append"
+    location          = "EU"
+    clustering_fields = ["campaign_name"]
+    time_partitioning = {
+      field = "date"
+      type  = "MONTH"
+    }
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.campaign_name,
+    data.funnel_export_field.impressions,
+    data.funnel_export_field.cost
+  ]
+
+  format {
+    type    = "parquet"
+    metrics = "export"
+  }
+
+  range {
+    rolling_start {
+      period  = "weeks"
+      periods = 1
+    }
+  }
+}
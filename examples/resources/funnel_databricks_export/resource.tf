@@ -0,0 +1,50 @@
+# Fetch export fields
+data "funnel_export_field" "date" {
+  workspace = var.workspace_id
+  id        = "date"
+}
+
+data "funnel_export_field" "campaign_name" {
+  workspace = var.workspace_id
+  id        = "campaign_name"
+}
+
+data "funnel_export_field" "cost" {
+  workspace = var.workspace_id
+  id        = "cost"
+}
+
+# Databricks export to a Unity Catalog table, authenticated with a write-only access token
+resource "funnel_databricks_export" "lakehouse" {
+  workspace = var.workspace_id
+  name      = "Daily Marketing Cost to Databricks"
+  enabled   = true
+  schedule  = "0 4 * * *" # Daily at 4 AM
+
+  destination {
+    host         = "adb-1234567890123456.7.azuredatabricks.net"
+    catalog      = "main"
+    schema_name  = "marketing"
+    table_name   = "funnel_cost"
+    warehouse_id = "abcdef0123456789"
+
+    # Never stored in the plan or state. Bump the version to send a rotated access token.
+    access_token_wo        = var.databricks_access_token
+    credentials_wo_version = 1
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.campaign_name,
+    data.funnel_export_field.cost
+  ]
+
+  format {
+    type    = "csv"
+    metrics = "export"
+  }
+
+  range {
+    start = "2024-01-01"
+  }
+}
@@ -0,0 +1,101 @@
+# Fetch export fields
+data "funnel_export_field" "date" {
+  workspace = var.workspace_id
+  id        = "date"
+}
+
+data "funnel_export_field" "cost" {
+  workspace = var.workspace_id
+  id        = "cost"
+}
+
+# GCS export, configured with the gcs destination block
+resource "funnel_export" "gcs" {
+  workspace = var.workspace_id
+  name      = "Daily Cost to GCS"
+  enabled   = true
+  schedule  = "0 2 * * *" # Daily at 2 AM
+
+  gcs {
+    bucket             = "my-funnel-exports"
+    path               = "cost"
+    output_id_template = "funnel_export_{date}"
+    credentials_ref    = "gcs-service-account-key"
+    gzip               = true
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.cost
+  ]
+
+  # Google Ads cost of brand campaigns or with more than 100 spend
+  filter = {
+    and = [
+      { field_id = "sourceType", operation = "eq", value = "adwords" },
+      {
+        or = [
+          { field_id = "campaign", operation = "contains", value = "brand" },
+          { field_id = "cost", operation = "gt", value = "100" },
+        ]
+      },
+    ]
+  }
+
+  format {
+    type    = "parquet"
+    metrics = "export"
+  }
+
+  range {
+    rolling_start {
+      period  = "days"
+      periods = -7
+    }
+    rolling_end {
+      period  = "days"
+      periods = -1
+    }
+  }
+}
+
+# Move an existing funnel_bigquery_export to funnel_export without recreating it
+moved {
+  from = funnel_bigquery_export.daily
+  to   = funnel_export.daily
+}
+
+resource "funnel_export" "daily" {
+  workspace = var.workspace_id
+  name      = "Daily Cost to BigQuery"
+  enabled   = true
+  schedule  = "0 3 * * *"
+
+  bigquery {
+    project_id         = "my-gcp-project"
+    dataset_id         = "funnel_marketing_data"
+    output_id_template = "daily_export_{date}"
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.cost
+  ]
+
+  # Raw Meld filter for what filter can't express, sent to Funnel as is
+  where_json = jsonencode({
+    "=and" = [
+      { "sourceType" = { "=in" = ["adwords", "facebookads"] } },
+      { "=not" = { "campaign" = { "=contains" = "test" } } },
+    ]
+  })
+
+  format {
+    type    = "parquet"
+    metrics = "export"
+  }
+
+  range {
+    start = "2024-01-01"
+  }
+}
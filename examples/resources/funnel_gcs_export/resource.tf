@@ -32,6 +32,14 @@ resource "funnel_gcs_export" "basic" {
     output_id_template = "funnel_export_{date}"
     credentials_ref    = "gcs-service-account-key"
     gzip               = true
+
+    # Move the summary file and leave out the schema file
+    summary_file = {
+      id_template = "summaries/{runId}"
+    }
+    schema_file = {
+      enabled = false
+    }
   }
 
   fields = [
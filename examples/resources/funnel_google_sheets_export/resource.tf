@@ -0,0 +1,45 @@
+# Fetch export fields
+data "funnel_export_field" "date" {
+  workspace = var.workspace_id
+  id        = "date"
+}
+
+data "funnel_export_field" "campaign_name" {
+  workspace = var.workspace_id
+  id        = "campaign_name"
+}
+
+data "funnel_export_field" "cost" {
+  workspace = var.workspace_id
+  id        = "cost"
+}
+
+# Google Sheets export replacing the data of a sheet on every run
+resource "funnel_google_sheets_export" "rollup" {
+  workspace = var.workspace_id
+  name      = "Daily Marketing Cost Rollup"
+  enabled   = true
+  schedule  = "0 4 * * *" # Daily at 4 AM
+
+  destination {
+    spreadsheet_id  = "1BxiMVs0XRA5nFMdKvBdBZjgmUUqptlbs74OgvE2upms"
+    sheet_name      = "Daily rollup"
+    write_mode      = "replace"
+    credentials_ref = "google-sheets-credentials"
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.campaign_name,
+    data.funnel_export_field.cost
+  ]
+
+  format {
+    type    = "csv"
+    metrics = "export"
+  }
+
+  range {
+    start = "2024-01-01"
+  }
+}
@@ -0,0 +1,50 @@
+# Fetch export fields
+data "funnel_export_field" "date" {
+  workspace = var.workspace_id
+  id        = "date"
+}
+
+data "funnel_export_field" "campaign_name" {
+  workspace = var.workspace_id
+  id        = "campaign_name"
+}
+
+data "funnel_export_field" "cost" {
+  workspace = var.workspace_id
+  id        = "cost"
+}
+
+# Redshift export authenticated with a write-only password
+resource "funnel_redshift_export" "finance" {
+  workspace = var.workspace_id
+  name      = "Daily Marketing Cost to Redshift"
+  enabled   = true
+  schedule  = "0 4 * * *" # Daily at 4 AM
+
+  destination {
+    cluster_endpoint = "examplecluster.abc123xyz789.us-west-2.redshift.amazonaws.com:5439"
+    database         = "finance"
+    schema_name      = "marketing"
+    table_name       = "funnel_cost"
+    username         = "funnel_export"
+
+    # Never stored in the plan or state. Bump the version to send a rotated password.
+    password_wo            = var.redshift_password
+    credentials_wo_version = 1
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.campaign_name,
+    data.funnel_export_field.cost
+  ]
+
+  format {
+    type    = "csv"
+    metrics = "export"
+  }
+
+  range {
+    start = "2024-01-01"
+  }
+}
@@ -0,0 +1,54 @@
+# Fetch export fields
+data "funnel_export_field" "date" {
+  workspace = var.workspace_id
+  id        = "date"
+}
+
+data "funnel_export_field" "campaign_name" {
+  workspace = var.workspace_id
+  id        = "campaign_name"
+}
+
+data "funnel_export_field" "cost" {
+  workspace = var.workspace_id
+  id        = "cost"
+}
+
+# S3 export that writes to the bucket through an IAM role
+resource "funnel_s3_export" "basic" {
+  workspace = var.workspace_id
+  name      = "Daily Marketing Data to S3"
+  enabled   = true
+  schedule  = "0 2 * * *" # Daily at 2 AM
+
+  destination {
+    bucket             = "my-funnel-exports"
+    path               = "marketing-data"
+    region             = "eu-west-1"
+    output_id_template = "funnel_export_{date}"
+    role_arn           = "arn:aws:iam::123456789012:role/funnel-export"
+    external_id        = var.funnel_external_id
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.campaign_name,
+    data.funnel_export_field.cost
+  ]
+
+  format {
+    type    = "parquet"
+    metrics = "export"
+  }
+
+  range {
+    rolling_start {
+      period  = "days"
+      periods = -7
+    }
+    rolling_end {
+      period  = "days"
+      periods = -1
+    }
+  }
+}
@@ -0,0 +1,52 @@
+# Fetch export fields
+data "funnel_export_field" "date" {
+  workspace = var.workspace_id
+  id        = "date"
+}
+
+data "funnel_export_field" "campaign_name" {
+  workspace = var.workspace_id
+  id        = "campaign_name"
+}
+
+data "funnel_export_field" "cost" {
+  workspace = var.workspace_id
+  id        = "cost"
+}
+
+# SFTP export authenticated with a write-only private key
+resource "funnel_sftp_export" "agency" {
+  workspace = var.workspace_id
+  name      = "Daily Marketing Cost to Agency SFTP"
+  enabled   = true
+  schedule  = "0 4 * * *" # Daily at 4 AM
+
+  destination {
+    host                 = "sftp.example.com"
+    port                 = 22
+    username             = "funnel"
+    path                 = "/uploads/funnel"
+    host_key_fingerprint = "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8"
+    gzip                 = true
+    output_id_template   = "funnel_cost_{date}"
+
+    # Never stored in the plan or state. Bump the version to send a rotated key.
+    private_key_wo         = file("~/.ssh/funnel_export")
+    credentials_wo_version = 1
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.campaign_name,
+    data.funnel_export_field.cost
+  ]
+
+  format {
+    type    = "csv"
+    metrics = "export"
+  }
+
+  range {
+    start = "2024-01-01"
+  }
+}
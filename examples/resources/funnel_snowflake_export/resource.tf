@@ -58,3 +58,89 @@ resource "funnel_snowflake_export" "basic" {
     }
   }
 }
+
+# Snowflake export with write-only credentials that are never stored in the state (Terraform 1.11 or later)
+# Bump credentials_wo_version to send a rotated token to Funnel
+resource "funnel_snowflake_export" "write_only" {
+  workspace = var.workspace_id
+  name      = "Daily Marketing Data to Snowflake with write-only credentials"
+  enabled   = true
+  schedule  = "0 5 * * *" # Daily at 5 AM
+
+  destination {
+    account_locator          = "xy12345.us-east-1"
+    database                 = "MARKETING_DB"
+    schema_name              = "FUNNEL_DATA"
+    table_name               = "DAILY_PERFORMANCE_WO"
+    username                 = "funnel_user"
+    personal_access_token_wo = var.snowflake_pat
+    credentials_wo_version   = 1
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.campaign_name,
+    data.funnel_export_field.impressions,
+    data.funnel_export_field.cost
+  ]
+
+  format {
+    type    = "parquet"
+    metrics = "export"
+  }
+
+  range {
+    rolling_start {
+      period  = "days"
+      periods = -7
+    }
+    rolling_end {
+      period  = "days"
+      periods = -1
+    }
+  }
+}
+
+# Snowflake export with a least-privilege role and warehouse, authenticated with an encrypted private key
+resource "funnel_snowflake_export" "key_pair" {
+  workspace = var.workspace_id
+  name      = "Daily Marketing Data to Snowflake with a key pair"
+  enabled   = true
+  schedule  = "0 6 * * *" # Daily at 6 AM
+
+  destination {
+    account_locator           = "myorg.myaccount"
+    database                  = "MARKETING_DB"
+    schema_name               = "FUNNEL_DATA"
+    table_name                = "DAILY_PERFORMANCE_KEY_PAIR"
+    username                  = "funnel_user"
+    role                      = "FUNNEL_EXPORT"
+    warehouse                 = "FUNNEL_WH"
+    private_key_wo            = var.snowflake_private_key
+    private_key_passphrase_wo = var.snowflake_private_key_passphrase
+    credentials_wo_version    = 1
+  }
+
+  fields = [
+    data.funnel_export_field.date,
+    data.funnel_export_field.campaign_name,
+    data.funnel_export_field.impressions,
+    data.funnel_export_field.cost
+  ]
+
+  format {
+    type    = "parquet"
+    metrics = "export"
+  }
+
+  range {
+    rolling_start {
+      period  = "days"
+      periods = -7
+    }
+    rolling_end {
+      period  = "days"
+      periods = -1
+    }
+  }
+}
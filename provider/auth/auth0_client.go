@@ -7,8 +7,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // TokenResponse represents the response from Auth0 token endpoint
@@ -40,43 +38,49 @@ func getAuth0Audience(environment string) string {
 	}
 }
 
-func GetAccessToken(clientID, clientSecret, environment string, ctx context.Context) (string, error) {
-	return getAccessTokenWithEndpoint(clientID, clientSecret, ctx, getAuth0Endpoint(environment), getAuth0Audience(environment))
+// Endpoint is the token endpoint and the audience access tokens are requested for.
+type Endpoint struct {
+	TokenURL string
+	Audience string
 }
 
-// Used in unit testing with a custom endpoint.
-func getAccessTokenWithEndpoint(clientID, clientSecret string, ctx context.Context, tokenEndpoint, audience string) (string, error) {
-	tflog.Info(ctx, "Getting Auth0 access token", map[string]any{"client_id": clientID, "audience": audience, "token_endpoint": tokenEndpoint})
-
-	token, err := fetchToken(clientID, clientSecret, audience, tokenEndpoint)
-	if err != nil {
-		return "", err
+// ResolveEndpoint returns the Auth0 endpoint of the environment.
+// A non-empty tokenURL or audience replaces the value derived from the environment.
+func ResolveEndpoint(environment, tokenURL, audience string) Endpoint {
+	endpoint := Endpoint{TokenURL: getAuth0Endpoint(environment), Audience: getAuth0Audience(environment)}
+	if tokenURL != "" {
+		endpoint.TokenURL = tokenURL
 	}
-
-	return "Bearer " + token.AccessToken, nil
+	if audience != "" {
+		endpoint.Audience = audience
+	}
+	return endpoint
 }
 
-func fetchToken(clientID, clientSecret, audience, tokenEndpoint string) (*TokenResponse, error) {
-	payload := map[string]string{
+func fetchToken(ctx context.Context, httpClient *http.Client, clientID, clientSecret, audience, tokenEndpoint string) (*TokenResponse, error) {
+	return requestToken(ctx, httpClient, tokenEndpoint, map[string]string{
 		"client_id":     clientID,
 		"client_secret": clientSecret,
 		"audience":      audience,
 		"grant_type":    "client_credentials",
-	}
+	})
+}
 
+// requestToken posts a token request with the given parameters to the token endpoint.
+func requestToken(ctx context.Context, httpClient *http.Client, tokenEndpoint string, payload map[string]string) (*TokenResponse, error) {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal token request: %w", err)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, tokenEndpoint, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to request token: %w", err)
 	}
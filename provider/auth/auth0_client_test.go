@@ -8,48 +8,90 @@ import (
 	"testing"
 )
 
-func TestGetAccessToken_ReturnsValidBearerToken(t *testing.T) {
+func TestClientCredentialsTokenSource_ReturnsBearerToken(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["grant_type"] != "client_credentials" || payload["client_id"] != "client-id" || payload["client_secret"] != "client-secret" || payload["audience"] != "audience" {
+			t.Errorf("unexpected token request %v", payload)
+		}
 		json.NewEncoder(w).Encode(TokenResponse{AccessToken: "test-token", TokenType: "Bearer", ExpiresIn: 3600})
 	}))
 	defer server.Close()
 
-	token, err := getAccessTokenWithEndpoint("client-id", "client-secret", context.Background(), server.URL, "audience")
+	source := NewClientCredentialsTokenSource(http.DefaultClient, "client-id", "client-secret", Endpoint{TokenURL: server.URL, Audience: "audience"})
+	token, err := source.Token(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if token != "Bearer test-token" {
-		t.Errorf("expected 'Bearer test-token', got %q", token)
+	if token.AuthorizationHeader() != "Bearer test-token" {
+		t.Errorf("expected 'Bearer test-token', got %q", token.AuthorizationHeader())
 	}
 }
 
-func TestGetAccessToken_ReturnsErrorOnHTTPFailure(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusUnauthorized)
-	}))
-	defer server.Close()
-
-	_, err := getAccessTokenWithEndpoint("client-id", "client-secret", context.Background(), server.URL, "audience")
-	if err == nil {
+func TestClientCredentialsTokenSource_ReturnsErrorOnNetworkFailure(t *testing.T) {
+	source := NewClientCredentialsTokenSource(http.DefaultClient, "client-id", "client-secret", Endpoint{TokenURL: "http://localhost:65535", Audience: "audience"})
+	if _, err := source.Token(context.Background()); err == nil {
 		t.Fatal("expected error, got nil")
 	}
 }
 
-func TestGetAccessToken_ReturnsErrorOnNetworkFailure(t *testing.T) {
-	_, err := getAccessTokenWithEndpoint("client-id", "client-secret", context.Background(), "http://localhost:65535", "audience")
-	if err == nil {
-		t.Fatal("expected error, got nil")
-	}
-}
-
-func TestGetAccessToken_ReturnsErrorOnInvalidJSON(t *testing.T) {
+func TestClientCredentialsTokenSource_ReturnsErrorOnInvalidJSON(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("invalid json"))
 	}))
 	defer server.Close()
 
-	_, err := getAccessTokenWithEndpoint("client-id", "client-secret", context.Background(), server.URL, "audience")
-	if err == nil {
+	source := NewClientCredentialsTokenSource(http.DefaultClient, "client-id", "client-secret", Endpoint{TokenURL: server.URL, Audience: "audience"})
+	if _, err := source.Token(context.Background()); err == nil {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestResolveEndpoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		tokenURL    string
+		audience    string
+		expected    Endpoint
+	}{
+		{
+			name:        "us",
+			environment: "us",
+			expected:    Endpoint{TokenURL: "https://login.funnel.io/oauth/token", Audience: "https://controlplane.setup.us.funnel.io"},
+		},
+		{
+			name:        "eu",
+			environment: "eu",
+			expected:    Endpoint{TokenURL: "https://login.funnel.io/oauth/token", Audience: "https://controlplane.setup.eu.funnel.io"},
+		},
+		{
+			name:        "stage",
+			environment: "stage",
+			expected:    Endpoint{TokenURL: "https://login.stage.funnel.io/oauth/token", Audience: "https://controlplane.setup.stage.funnel.io"},
+		},
+		{
+			name:        "token url override",
+			environment: "eu",
+			tokenURL:    "http://localhost:8080/oauth/token",
+			expected:    Endpoint{TokenURL: "http://localhost:8080/oauth/token", Audience: "https://controlplane.setup.eu.funnel.io"},
+		},
+		{
+			name:        "token url and audience override",
+			environment: "us",
+			tokenURL:    "http://localhost:8080/oauth/token",
+			audience:    "http://localhost:3000",
+			expected:    Endpoint{TokenURL: "http://localhost:8080/oauth/token", Audience: "http://localhost:3000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := ResolveEndpoint(tt.environment, tt.tokenURL, tt.audience)
+			if endpoint != tt.expected {
+				t.Errorf("ResolveEndpoint(%q, %q, %q) = %+v, expected %+v", tt.environment, tt.tokenURL, tt.audience, endpoint, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Client assertions are short-lived since a new one is signed for every token request.
+const clientAssertionLifetime = 5 * time.Minute
+
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// ParsePrivateKey parses a PEM encoded RSA or P-256 EC private key in PKCS#1, SEC 1 or PKCS#8 form.
+func ParsePrivateKey(pemData []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("no PEM block found in the private key")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q, expected a private key", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, errors.New("unsupported EC curve, expected P-256")
+		}
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T, expected RSA or EC P-256", key)
+	}
+}
+
+// signClientAssertion signs a JWT that authenticates the client at the token endpoint (RFC 7523).
+// RSA keys sign with RS256 and EC P-256 keys with ES256.
+func signClientAssertion(key crypto.Signer, keyID, clientID, tokenEndpoint string, now time.Time) (string, error) {
+	header := map[string]string{"typ": "JWT"}
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		header["alg"] = "RS256"
+	case *ecdsa.PrivateKey:
+		header["alg"] = "ES256"
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", key)
+	}
+	if keyID != "" {
+		header["kid"] = keyID
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", fmt.Errorf("failed to generate assertion ID: %w", err)
+	}
+
+	claims := map[string]any{
+		"iss": clientID,
+		"sub": clientID,
+		"aud": tokenEndpoint,
+		"jti": hex.EncodeToString(jti),
+		"iat": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest[:])
+		if err == nil {
+			// JWS uses the fixed size concatenation of r and s instead of ASN.1.
+			signature = make([]byte, 64)
+			r.FillBytes(signature[:32])
+			s.FillBytes(signature[32:])
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to sign client assertion: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// NewPrivateKeyJWTTokenSource returns a token source that authenticates with the client credentials grant
+// and a client assertion signed with the private key instead of a client secret.
+func NewPrivateKeyJWTTokenSource(httpClient *http.Client, clientID string, key crypto.Signer, keyID string, endpoint Endpoint) TokenSource {
+	return newCachingTokenSource(func(ctx context.Context) (*TokenResponse, error) {
+		tflog.Info(ctx, "Getting Auth0 access token with a private key JWT", map[string]any{"client_id": clientID, "audience": endpoint.Audience, "token_endpoint": endpoint.TokenURL})
+
+		assertion, err := signClientAssertion(key, keyID, clientID, endpoint.TokenURL, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return requestToken(ctx, httpClient, endpoint.TokenURL, map[string]string{
+			"client_id":             clientID,
+			"client_assertion":      assertion,
+			"client_assertion_type": clientAssertionType,
+			"audience":              endpoint.Audience,
+			"grant_type":            "client_credentials",
+		})
+	})
+}
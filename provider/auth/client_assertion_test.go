@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func verifyClientAssertion(t *testing.T, assertion string, publicKey crypto.PublicKey) map[string]any {
+	t.Helper()
+
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a JWT with 3 parts, got %d", len(parts))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("invalid signature encoding: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			t.Fatalf("invalid RS256 signature: %v", err)
+		}
+	case *ecdsa.PublicKey:
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(key, digest[:], r, s) {
+			t.Fatal("invalid ES256 signature")
+		}
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("invalid claims encoding: %v", err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("invalid claims: %v", err)
+	}
+	return claims
+}
+
+func TestPrivateKeyJWTTokenSource_SendsSignedClientAssertion(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	for name, key := range map[string]crypto.Signer{"RS256": rsaKey, "ES256": ecKey} {
+		t.Run(name, func(t *testing.T) {
+			var serverURL string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload map[string]string
+				json.NewDecoder(r.Body).Decode(&payload)
+
+				if payload["grant_type"] != "client_credentials" || payload["client_assertion_type"] != clientAssertionType {
+					t.Errorf("unexpected grant: %v", payload)
+				}
+				if _, ok := payload["client_secret"]; ok {
+					t.Error("expected no client secret")
+				}
+
+				claims := verifyClientAssertion(t, payload["client_assertion"], key.Public())
+				if claims["iss"] != "client-id" || claims["sub"] != "client-id" || claims["aud"] != serverURL {
+					t.Errorf("unexpected claims: %v", claims)
+				}
+
+				json.NewEncoder(w).Encode(TokenResponse{AccessToken: "test-token", TokenType: "Bearer", ExpiresIn: 3600})
+			}))
+			defer server.Close()
+			serverURL = server.URL
+
+			source := NewPrivateKeyJWTTokenSource(http.DefaultClient, "client-id", key, "key-1", Endpoint{TokenURL: server.URL, Audience: "audience"})
+			token, err := source.Token(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token.AccessToken != "test-token" {
+				t.Errorf("expected 'test-token', got %q", token.AccessToken)
+			}
+		})
+	}
+}
+
+func TestSignClientAssertion_SetsHeaderAndExpiry(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	now := time.Unix(1_800_000_000, 0)
+
+	assertion, err := signClientAssertion(key, "key-1", "client-id", "https://login.example.com/oauth/token", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	headerJSON, _ := base64.RawURLEncoding.DecodeString(strings.Split(assertion, ".")[0])
+	var header map[string]string
+	json.Unmarshal(headerJSON, &header)
+	if header["alg"] != "ES256" || header["kid"] != "key-1" {
+		t.Errorf("unexpected header: %v", header)
+	}
+
+	claims := verifyClientAssertion(t, assertion, key.Public())
+	if claims["exp"] != float64(now.Add(clientAssertionLifetime).Unix()) {
+		t.Errorf("unexpected expiry: %v", claims["exp"])
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384Key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(rsaKey)
+	sec1, _ := x509.MarshalECPrivateKey(ecKey)
+	p384, _ := x509.MarshalECPrivateKey(p384Key)
+
+	tests := []struct {
+		name    string
+		pem     []byte
+		wantErr bool
+	}{
+		{name: "PKCS#1 RSA", pem: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})},
+		{name: "PKCS#8 RSA", pem: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})},
+		{name: "SEC 1 EC", pem: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1})},
+		{name: "P-384 EC", pem: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: p384}), wantErr: true},
+		{name: "certificate", pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("cert")}), wantErr: true},
+		{name: "not PEM", pem: []byte("not a key"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePrivateKey(tt.pem)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
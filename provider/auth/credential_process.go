@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// CredentialProcessOutput is the JSON a credential process prints on stdout.
+// It holds either a client ID and secret for the client credentials grant,
+// or a ready access token with an optional expiry.
+type CredentialProcessOutput struct {
+	ClientId     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret"`
+	AccessToken  string    `json:"access_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// runCredentialProcess runs the command through the shell and parses its output.
+// The command's stderr is included in the error when it fails.
+func runCredentialProcess(ctx context.Context, command string) (*CredentialProcessOutput, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Children of the shell can keep the output open after the shell is killed on cancellation.
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("credential process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var output CredentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("failed to decode credential process output: %w", err)
+	}
+
+	switch {
+	case output.AccessToken != "":
+		return &output, nil
+	case output.ClientId != "" && output.ClientSecret != "":
+		return &output, nil
+	default:
+		return nil, errors.New("credential process output has neither access_token nor client_id and client_secret")
+	}
+}
+
+// NewCredentialProcessTokenSource returns a token source that runs an external command for credentials.
+// An access token from the command is used until it expires. A client ID and secret are exchanged for an
+// access token with the client credentials grant. The command runs again when the token is about to expire.
+func NewCredentialProcessTokenSource(httpClient *http.Client, command string, endpoint Endpoint) TokenSource {
+	source := newCachingTokenSource(nil)
+	source.fetch = func(ctx context.Context) (*TokenResponse, error) {
+		tflog.Info(ctx, "Running credential process for Funnel credentials")
+
+		output, err := runCredentialProcess(ctx, command)
+		if err != nil {
+			return nil, err
+		}
+
+		if output.AccessToken == "" {
+			return fetchToken(ctx, httpClient, output.ClientId, output.ClientSecret, endpoint.Audience, endpoint.TokenURL)
+		}
+
+		token := &TokenResponse{AccessToken: output.AccessToken, TokenType: "Bearer"}
+		if !output.ExpiresAt.IsZero() {
+			expiresIn := int(output.ExpiresAt.Sub(source.now()).Seconds())
+			if expiresIn <= 0 {
+				return nil, fmt.Errorf("credential process returned an access token that expired at %s", output.ExpiresAt.Format(time.RFC3339))
+			}
+			token.ExpiresIn = expiresIn
+		}
+		return token, nil
+	}
+	return source
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func skipWithoutShell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process tests use a POSIX shell")
+	}
+}
+
+func TestCredentialProcessTokenSource_UsesAccessTokenUntilExpiry(t *testing.T) {
+	skipWithoutShell(t)
+
+	calls := filepath.Join(t.TempDir(), "calls")
+	expiresAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	command := fmt.Sprintf(`echo run >> %s; echo '{"access_token":"process-token","expires_at":"%s"}'`, calls, expiresAt)
+
+	source := NewCredentialProcessTokenSource(http.DefaultClient, command, Endpoint{})
+	for range 3 {
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token.AccessToken != "process-token" {
+			t.Errorf("expected 'process-token', got %q", token.AccessToken)
+		}
+	}
+
+	data, _ := os.ReadFile(calls)
+	if runs := strings.Count(string(data), "run"); runs != 1 {
+		t.Errorf("expected the process to run once, ran %d times", runs)
+	}
+}
+
+func TestCredentialProcessTokenSource_ExchangesClientCredentials(t *testing.T) {
+	skipWithoutShell(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["client_id"] != "process-id" || payload["client_secret"] != "process-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(TokenResponse{AccessToken: "test-token", TokenType: "Bearer", ExpiresIn: 3600})
+	}))
+	defer server.Close()
+
+	command := `echo '{"client_id":"process-id","client_secret":"process-secret"}'`
+	source := NewCredentialProcessTokenSource(http.DefaultClient, command, Endpoint{TokenURL: server.URL, Audience: "audience"})
+
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "test-token" {
+		t.Errorf("expected 'test-token', got %q", token.AccessToken)
+	}
+}
+
+func TestCredentialProcessTokenSource_ReturnsErrors(t *testing.T) {
+	skipWithoutShell(t)
+
+	expiredAt := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	tests := map[string]struct {
+		command  string
+		contains string
+	}{
+		"failing command":   {command: `echo "vault is sealed" >&2; exit 1`, contains: "vault is sealed"},
+		"invalid json":      {command: `echo not json`, contains: "decode"},
+		"no credentials":    {command: `echo '{"client_id":"process-id"}'`, contains: "neither"},
+		"expired token":     {command: fmt.Sprintf(`echo '{"access_token":"process-token","expires_at":"%s"}'`, expiredAt), contains: "expired"},
+		"cancelled context": {command: `sleep 5`, contains: "failed"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			_, err := NewCredentialProcessTokenSource(http.DefaultClient, tt.command, Endpoint{}).Token(ctx)
+			if err == nil || !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("expected an error containing %q, got %v", tt.contains, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfile is used when neither the provider configuration nor FUNNEL_PROFILE selects a profile.
+const DefaultProfile = "default"
+
+// ErrProfileNotFound is returned by LoadProfile when the credentials file has no section for the profile.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile holds the settings of one section in the credentials file, keyed by provider attribute name.
+type Profile map[string]string
+
+// DefaultCredentialsFile returns the path of the credentials file, ~/.funnel/credentials.
+func DefaultCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find home directory: %w", err)
+	}
+	return filepath.Join(home, ".funnel", "credentials"), nil
+}
+
+// LoadProfile reads a profile from an INI style credentials file:
+//
+//	[default]
+//	subscription_id = fsXXXXXXXXXXX
+//	client_id       = ...
+//	client_secret   = ...
+//
+// Lines starting with # or ; are comments.
+func LoadProfile(path, name string) (Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var profile Profile
+	// Lines before the first section header belong to no profile
+	inProfile := false
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s:%d: invalid section header %q", path, lineNumber, line)
+			}
+			inProfile = strings.TrimSpace(line[1:len(line)-1]) == name
+			if inProfile && profile == nil {
+				profile = Profile{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected \"key = value\"", path, lineNumber)
+		}
+		if inProfile {
+			profile[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	if profile == nil {
+		return nil, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, name, path)
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("could not write credentials file: %v", err)
+	}
+	return path
+}
+
+func TestLoadProfile_ReadsNamedProfile(t *testing.T) {
+	path := writeCredentialsFile(t, `
+# Funnel credentials
+[default]
+client_id = default-id
+
+[ci]
+subscription_id = fs123
+client_id       = ci-id
+client_secret   = ci=secret
+; environment = us
+environment     = eu
+`)
+
+	profile, err := LoadProfile(path, "ci")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := Profile{"subscription_id": "fs123", "client_id": "ci-id", "client_secret": "ci=secret", "environment": "eu"}
+	if len(profile) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, profile)
+	}
+	for key, value := range expected {
+		if profile[key] != value {
+			t.Errorf("expected %s = %q, got %q", key, value, profile[key])
+		}
+	}
+}
+
+func TestLoadProfile_ReturnsErrorForMissingProfile(t *testing.T) {
+	path := writeCredentialsFile(t, "[default]\nclient_id = default-id\n")
+
+	_, err := LoadProfile(path, "ci")
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Fatalf("expected ErrProfileNotFound, got %v", err)
+	}
+}
+
+func TestLoadProfile_IgnoresLinesBeforeTheFirstSection(t *testing.T) {
+	path := writeCredentialsFile(t, "client_id = no-section-id\n[default]\nclient_id = default-id\n")
+
+	_, err := LoadProfile(path, "")
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Fatalf("expected ErrProfileNotFound, got %v", err)
+	}
+
+	profile, err := LoadProfile(path, DefaultProfile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(profile) != 1 || profile["client_id"] != "default-id" {
+		t.Errorf("expected only the default profile, got %v", profile)
+	}
+}
+
+func TestLoadProfile_ReturnsErrorForMissingFile(t *testing.T) {
+	_, err := LoadProfile(filepath.Join(t.TempDir(), "credentials"), DefaultProfile)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestLoadProfile_ReturnsErrorForMalformedLine(t *testing.T) {
+	path := writeCredentialsFile(t, "[default]\nclient_id\n")
+
+	if _, err := LoadProfile(path, DefaultProfile); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
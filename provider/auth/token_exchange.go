@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	// DefaultSubjectTokenType is the type of the federated token sent in a token exchange.
+	DefaultSubjectTokenType = "urn:ietf:params:oauth:token-type:id_token"
+)
+
+// IDTokenSource returns the federated ID token that is exchanged for a Funnel access token.
+// It is called for every exchange since workload identity tokens are short-lived and rotated.
+type IDTokenSource func(ctx context.Context) (string, error)
+
+// IDTokenFromFile reads the ID token from a file, e.g. a projected Kubernetes service account token.
+func IDTokenFromFile(path string) IDTokenSource {
+	return func(ctx context.Context) (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read ID token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("ID token file %s is empty", path)
+		}
+		return token, nil
+	}
+}
+
+// IDTokenFromEnv reads the ID token from an environment variable.
+func IDTokenFromEnv(name string) IDTokenSource {
+	return func(ctx context.Context) (string, error) {
+		token := strings.TrimSpace(os.Getenv(name))
+		if token == "" {
+			return "", fmt.Errorf("environment variable %s with the ID token is not set", name)
+		}
+		return token, nil
+	}
+}
+
+// HasGitHubActionsIDToken reports if the provider runs in a GitHub Actions job with the id-token: write permission.
+func HasGitHubActionsIDToken() bool {
+	return os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL") != "" && os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN") != ""
+}
+
+// GitHubActionsIDToken requests an ID token for the audience from the GitHub Actions OIDC provider.
+func GitHubActionsIDToken(httpClient *http.Client, audience string) IDTokenSource {
+	return func(ctx context.Context) (string, error) {
+		requestURL, err := url.Parse(os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"))
+		if err != nil || requestURL.Host == "" {
+			return "", errors.New("ACTIONS_ID_TOKEN_REQUEST_URL is not a valid URL")
+		}
+		if audience != "" {
+			query := requestURL.Query()
+			query.Set("audience", audience)
+			requestURL.RawQuery = query.Encode()
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL.String(), nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to create ID token request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN"))
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to request GitHub Actions ID token: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return "", fmt.Errorf("failed to obtain GitHub Actions ID token: %d %s - %s", resp.StatusCode, resp.Status, string(body))
+		}
+
+		var tokenResp struct {
+			Value string `json:"value"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+			return "", fmt.Errorf("failed to decode GitHub Actions ID token response: %w", err)
+		}
+		if tokenResp.Value == "" {
+			return "", errors.New("GitHub Actions ID token response has no token")
+		}
+
+		return tokenResp.Value, nil
+	}
+}
+
+// NewTokenExchangeTokenSource returns a token source that exchanges a federated ID token for an access token (RFC 8693).
+func NewTokenExchangeTokenSource(httpClient *http.Client, clientID string, idToken IDTokenSource, subjectTokenType string, endpoint Endpoint) TokenSource {
+	if subjectTokenType == "" {
+		subjectTokenType = DefaultSubjectTokenType
+	}
+
+	return newCachingTokenSource(func(ctx context.Context) (*TokenResponse, error) {
+		tflog.Info(ctx, "Exchanging ID token for Auth0 access token", map[string]any{"client_id": clientID, "audience": endpoint.Audience, "token_endpoint": endpoint.TokenURL})
+
+		subjectToken, err := idToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return requestToken(ctx, httpClient, endpoint.TokenURL, map[string]string{
+			"client_id":          clientID,
+			"subject_token":      subjectToken,
+			"subject_token_type": subjectTokenType,
+			"audience":           endpoint.Audience,
+			"grant_type":         tokenExchangeGrantType,
+		})
+	})
+}
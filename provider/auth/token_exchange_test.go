@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTokenExchangeTokenSource_ExchangesIDToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+
+		expected := map[string]string{
+			"client_id":          "client-id",
+			"subject_token":      "id-token",
+			"subject_token_type": DefaultSubjectTokenType,
+			"audience":           "audience",
+			"grant_type":         tokenExchangeGrantType,
+		}
+		for key, value := range expected {
+			if payload[key] != value {
+				t.Errorf("expected %s = %q, got %q", key, value, payload[key])
+			}
+		}
+
+		json.NewEncoder(w).Encode(TokenResponse{AccessToken: "test-token", TokenType: "Bearer", ExpiresIn: 3600})
+	}))
+	defer server.Close()
+
+	idToken := func(ctx context.Context) (string, error) { return "id-token", nil }
+	source := NewTokenExchangeTokenSource(http.DefaultClient, "client-id", idToken, "", Endpoint{TokenURL: server.URL, Audience: "audience"})
+
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "test-token" {
+		t.Errorf("expected 'test-token', got %q", token.AccessToken)
+	}
+}
+
+func TestTokenExchangeTokenSource_ReturnsErrorOnHTTPFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	idToken := func(ctx context.Context) (string, error) { return "id-token", nil }
+	source := NewTokenExchangeTokenSource(http.DefaultClient, "client-id", idToken, "", Endpoint{TokenURL: server.URL, Audience: "audience"})
+
+	if _, err := source.Token(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestIDTokenFromFile_ReadsTrimmedToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(path, []byte("id-token\n"), 0o600)
+
+	token, err := IDTokenFromFile(path)(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "id-token" {
+		t.Errorf("expected 'id-token', got %q", token)
+	}
+
+	if _, err := IDTokenFromFile(filepath.Join(t.TempDir(), "missing"))(context.Background()); err == nil {
+		t.Fatal("expected error for a missing file, got nil")
+	}
+}
+
+func TestIDTokenFromEnv_RequiresVariable(t *testing.T) {
+	t.Setenv("FUNNEL_TEST_ID_TOKEN", "")
+	if _, err := IDTokenFromEnv("FUNNEL_TEST_ID_TOKEN")(context.Background()); err == nil {
+		t.Fatal("expected error for an unset variable, got nil")
+	}
+
+	t.Setenv("FUNNEL_TEST_ID_TOKEN", "id-token")
+	token, err := IDTokenFromEnv("FUNNEL_TEST_ID_TOKEN")(context.Background())
+	if err != nil || token != "id-token" {
+		t.Errorf("expected 'id-token', got %q, %v", token, err)
+	}
+}
+
+func TestGitHubActionsIDToken_RequestsTokenForAudience(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer request-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("api-version") != "2.0" || r.URL.Query().Get("audience") != "funnel" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"value":"github-id-token"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", server.URL+"?api-version=2.0")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+	if !HasGitHubActionsIDToken() {
+		t.Fatal("expected the GitHub Actions ID token to be available")
+	}
+
+	token, err := GitHubActionsIDToken(http.DefaultClient, "funnel")(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "github-id-token" {
+		t.Errorf("expected 'github-id-token', got %q", token)
+	}
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Tokens are refreshed this long before they expire so they don't expire in the middle of a request.
+const tokenExpiryMargin = 60 * time.Second
+
+// Token is an access token for the Funnel API together with its expiry.
+// A zero Expiry means the token endpoint did not tell when the token expires.
+type Token struct {
+	AccessToken string
+	Expiry      time.Time
+}
+
+// AuthorizationHeader returns the value for the Authorization header of a Funnel API request.
+func (t *Token) AuthorizationHeader() string {
+	return "Bearer " + t.AccessToken
+}
+
+func (t *Token) validAt(now time.Time) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || now.Add(tokenExpiryMargin).Before(t.Expiry)
+}
+
+// TokenSource hands out access tokens for the Funnel API.
+type TokenSource interface {
+	// Token returns a valid token and fetches a new one when the cached token is about to expire.
+	Token(ctx context.Context) (*Token, error)
+	// Invalidate drops the cached token so the next call to Token fetches a new one.
+	Invalidate()
+}
+
+type tokenFetcher func(ctx context.Context) (*TokenResponse, error)
+
+// cachingTokenSource caches a fetched token until it is about to expire. It is safe for concurrent use.
+type cachingTokenSource struct {
+	mu    sync.Mutex
+	fetch tokenFetcher
+	token *Token
+	now   func() time.Time
+}
+
+func newCachingTokenSource(fetch tokenFetcher) *cachingTokenSource {
+	return &cachingTokenSource{fetch: fetch, now: time.Now}
+}
+
+func (s *cachingTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token.validAt(now) {
+		return s.token, nil
+	}
+
+	tflog.Debug(ctx, "Fetching a new Funnel access token")
+	resp, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &Token{AccessToken: resp.AccessToken}
+	if resp.ExpiresIn > 0 {
+		token.Expiry = now.Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+	s.token = token
+
+	return token, nil
+}
+
+func (s *cachingTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = nil
+}
+
+// NewClientCredentialsTokenSource returns a token source that authenticates with the Auth0 client credentials grant.
+func NewClientCredentialsTokenSource(httpClient *http.Client, clientID, clientSecret string, endpoint Endpoint) TokenSource {
+	return newClientCredentialsTokenSourceWithEndpoint(httpClient, clientID, clientSecret, endpoint.TokenURL, endpoint.Audience)
+}
+
+func newClientCredentialsTokenSourceWithEndpoint(httpClient *http.Client, clientID, clientSecret, tokenEndpoint, audience string) *cachingTokenSource {
+	return newCachingTokenSource(func(ctx context.Context) (*TokenResponse, error) {
+		tflog.Info(ctx, "Getting Auth0 access token", map[string]any{"client_id": clientID, "audience": audience, "token_endpoint": tokenEndpoint})
+		return fetchToken(ctx, httpClient, clientID, clientSecret, audience, tokenEndpoint)
+	})
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+// StaticTokenSource returns a token source that always hands out the given access token.
+func StaticTokenSource(accessToken string) TokenSource {
+	return staticTokenSource{token: &Token{AccessToken: accessToken}}
+}
+
+func (s staticTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+func (s staticTokenSource) Invalidate() {}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCountingTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		json.NewEncoder(w).Encode(TokenResponse{AccessToken: "test-token", TokenType: "Bearer", ExpiresIn: expiresIn})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestTokenSource_CachesTokenUntilExpiry(t *testing.T) {
+	server, calls := newCountingTokenServer(t, 3600)
+	source := newClientCredentialsTokenSourceWithEndpoint(http.DefaultClient, "client-id", "client-secret", server.URL, "audience")
+
+	for range 3 {
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token.AuthorizationHeader() != "Bearer test-token" {
+			t.Errorf("expected 'Bearer test-token', got %q", token.AuthorizationHeader())
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 token request, got %d", calls.Load())
+	}
+}
+
+func TestTokenSource_RefreshesTokenBeforeExpiry(t *testing.T) {
+	server, calls := newCountingTokenServer(t, 3600)
+	source := newClientCredentialsTokenSourceWithEndpoint(http.DefaultClient, "client-id", "client-secret", server.URL, "audience")
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	source.now = func() time.Time { return now }
+
+	if _, err := source.Token(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Still valid outside of the expiry margin.
+	now = now.Add(time.Hour - tokenExpiryMargin - time.Second)
+	if _, err := source.Token(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 token request, got %d", calls.Load())
+	}
+
+	// Within the expiry margin a new token is fetched.
+	now = now.Add(2 * time.Second)
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 token requests, got %d", calls.Load())
+	}
+	if !token.Expiry.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected expiry %v, got %v", now.Add(time.Hour), token.Expiry)
+	}
+}
+
+func TestTokenSource_InvalidateFetchesNewToken(t *testing.T) {
+	server, calls := newCountingTokenServer(t, 3600)
+	source := newClientCredentialsTokenSourceWithEndpoint(http.DefaultClient, "client-id", "client-secret", server.URL, "audience")
+
+	if _, err := source.Token(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	source.Invalidate()
+	if _, err := source.Token(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 token requests, got %d", calls.Load())
+	}
+}
+
+func TestTokenSource_ReturnsErrorOnHTTPFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	source := newClientCredentialsTokenSourceWithEndpoint(http.DefaultClient, "client-id", "client-secret", server.URL, "audience")
+	if _, err := source.Token(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"terraform-provider-funnel/provider/auth"
+	"terraform-provider-funnel/provider/common"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Authentication methods of the auth attribute.
+const (
+	authMethodClientCredentials = "client_credentials"
+	authMethodPrivateKeyJWT     = "private_key_jwt"
+	authMethodTokenExchange     = "token_exchange"
+)
+
+func authSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Selects how the provider authenticates. Defaults to the `client_credentials` method with `client_id` and `client_secret`.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				MarkdownDescription: "Authentication method. One of `client_credentials`, `private_key_jwt` or `token_exchange`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(authMethodClientCredentials, authMethodPrivateKeyJWT, authMethodTokenExchange),
+				},
+			},
+			"private_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded RSA or EC P-256 private key that signs the client assertion for `private_key_jwt`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("private_key_file")),
+				},
+			},
+			"private_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file with the PEM encoded private key for `private_key_jwt`.",
+				Optional:            true,
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "Key ID (`kid`) of the private key registered for the client. Used by `private_key_jwt`.",
+				Optional:            true,
+			},
+			"id_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file with the federated ID token to exchange with `token_exchange`, e.g. a projected Kubernetes service account token. The file is read again for every exchange.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("id_token_env")),
+				},
+			},
+			"id_token_env": schema.StringAttribute{
+				MarkdownDescription: "Name of the environment variable with the federated ID token to exchange with `token_exchange`.",
+				Optional:            true,
+			},
+			"id_token_audience": schema.StringAttribute{
+				MarkdownDescription: "Audience of the ID token requested from GitHub Actions. Used by `token_exchange` when neither `id_token_file` nor `id_token_env` is set.",
+				Optional:            true,
+			},
+			"subject_token_type": schema.StringAttribute{
+				MarkdownDescription: "Token type of the federated ID token in the token exchange. Default `urn:ietf:params:oauth:token-type:id_token`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// authMethod returns the configured authentication method, client_credentials when there is no auth attribute.
+func authMethod(config *common.FunnelProviderAuthModel) string {
+	if config == nil || config.Method.IsNull() || config.Method.IsUnknown() {
+		return authMethodClientCredentials
+	}
+	return config.Method.ValueString()
+}
+
+// newTokenSource creates the token source for the configured authentication method or credential process.
+// The returned path points at the attribute that caused an error.
+func newTokenSource(httpClient *http.Client, config *common.FunnelProviderAuthModel, credentialProcess, clientID, clientSecret string, endpoint auth.Endpoint) (auth.TokenSource, path.Path, error) {
+	authPath := path.Root("auth")
+
+	if credentialProcess != "" {
+		return auth.NewCredentialProcessTokenSource(httpClient, credentialProcess, endpoint), path.Empty(), nil
+	}
+
+	switch authMethod(config) {
+	case authMethodPrivateKeyJWT:
+		keyPEM := []byte(config.PrivateKeyPEM.ValueString())
+		if !config.PrivateKeyFile.IsNull() {
+			data, err := os.ReadFile(config.PrivateKeyFile.ValueString())
+			if err != nil {
+				return nil, authPath.AtName("private_key_file"), fmt.Errorf("could not read private key file: %w", err)
+			}
+			keyPEM = data
+		}
+		if len(keyPEM) == 0 {
+			return nil, authPath, errors.New("the private_key_jwt method requires private_key_pem or private_key_file")
+		}
+
+		key, err := auth.ParsePrivateKey(keyPEM)
+		if err != nil {
+			return nil, authPath.AtName("private_key_pem"), err
+		}
+
+		return auth.NewPrivateKeyJWTTokenSource(httpClient, clientID, key, config.KeyId.ValueString(), endpoint), path.Empty(), nil
+
+	case authMethodTokenExchange:
+		var idToken auth.IDTokenSource
+		switch {
+		case !config.IdTokenFile.IsNull():
+			idToken = auth.IDTokenFromFile(config.IdTokenFile.ValueString())
+		case !config.IdTokenEnv.IsNull():
+			idToken = auth.IDTokenFromEnv(config.IdTokenEnv.ValueString())
+		case auth.HasGitHubActionsIDToken():
+			idToken = auth.GitHubActionsIDToken(httpClient, config.IdTokenAudience.ValueString())
+		default:
+			return nil, authPath, errors.New("the token_exchange method requires id_token_file or id_token_env outside of GitHub Actions jobs with the id-token: write permission")
+		}
+
+		return auth.NewTokenExchangeTokenSource(httpClient, clientID, idToken, config.SubjectTokenType.ValueString(), endpoint), path.Empty(), nil
+
+	default:
+		return auth.NewClientCredentialsTokenSource(httpClient, clientID, clientSecret, endpoint), path.Empty(), nil
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"terraform-provider-funnel/provider/auth"
+	"terraform-provider-funnel/provider/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newAuthModel(method string) *common.FunnelProviderAuthModel {
+	return &common.FunnelProviderAuthModel{
+		Method:           types.StringValue(method),
+		PrivateKeyPEM:    types.StringNull(),
+		PrivateKeyFile:   types.StringNull(),
+		KeyId:            types.StringNull(),
+		IdTokenFile:      types.StringNull(),
+		IdTokenEnv:       types.StringNull(),
+		IdTokenAudience:  types.StringNull(),
+		SubjectTokenType: types.StringNull(),
+	}
+}
+
+func TestNewTokenSource_RequiresMethodSettings(t *testing.T) {
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", "")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "")
+
+	invalidKey := newAuthModel(authMethodPrivateKeyJWT)
+	invalidKey.PrivateKeyPEM = types.StringValue("not a key")
+
+	tests := map[string]*common.FunnelProviderAuthModel{
+		"private_key_jwt without key":        newAuthModel(authMethodPrivateKeyJWT),
+		"private_key_jwt with invalid key":   invalidKey,
+		"token_exchange without an ID token": newAuthModel(authMethodTokenExchange),
+	}
+
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := newTokenSource(http.DefaultClient, config, "", "client-id", "", auth.Endpoint{}); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestNewTokenSource_DefaultsToClientCredentials(t *testing.T) {
+	if authMethod(nil) != authMethodClientCredentials {
+		t.Errorf("expected %s, got %s", authMethodClientCredentials, authMethod(nil))
+	}
+
+	if _, _, err := newTokenSource(http.DefaultClient, nil, "", "client-id", "client-secret", auth.Endpoint{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
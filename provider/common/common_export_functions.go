@@ -1,6 +1,11 @@
 package common
 
 import (
+	"context"
+	"terraform-provider-funnel/provider/planmodifiers"
+	"terraform-provider-funnel/provider/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -87,18 +92,22 @@ type ExportFilterJSON struct {
 }
 
 type ExportShared struct {
-	Name            types.String    `tfsdk:"name"`
-	Id              types.String    `tfsdk:"id"`
-	Schedule        types.String    `tfsdk:"schedule"`
-	Workspace       types.String    `tfsdk:"workspace"`
-	Notes           types.String    `tfsdk:"notes"`
-	Currency        types.String    `tfsdk:"currency"`
-	Fields          []ExportField   `tfsdk:"fields"`
-	Format          ExportFormat    `tfsdk:"format"`
-	PartitionSchema PartitionSchema `tfsdk:"partition_schema"`
-	Range           ExportRange     `tfsdk:"range"`
-	Enabled         types.Bool      `tfsdk:"enabled"`
-	Filters         []ExportFilter  `tfsdk:"filters"`
+	Name            types.String            `tfsdk:"name"`
+	Id              types.String            `tfsdk:"id"`
+	Schedule        types.String            `tfsdk:"schedule"`
+	ScheduleConfig  *ScheduleConfig         `tfsdk:"schedule_config"`
+	Workspace       types.String            `tfsdk:"workspace"`
+	Notes           types.String            `tfsdk:"notes"`
+	Currency        types.String            `tfsdk:"currency"`
+	Fields          []ExportField           `tfsdk:"fields"`
+	Format          ExportFormat            `tfsdk:"format"`
+	PartitionSchema PartitionSchema         `tfsdk:"partition_schema"`
+	Range           ExportRange             `tfsdk:"range"`
+	Enabled         types.Bool              `tfsdk:"enabled"`
+	Filters         []ExportFilter          `tfsdk:"filters"`
+	Filter          *ExportFilterExpression `tfsdk:"filter"`
+	WhereJSON       types.String            `tfsdk:"where_json"`
+	Timeouts        timeouts.Value          `tfsdk:"timeouts"`
 }
 
 // In Funnel the fields array and the range object are part of a query object.
@@ -135,7 +144,14 @@ type ExportSharedJSON struct {
 	Filters              []ExportFilterJSON  `json:"-"`
 }
 
-func GetExportSchema(destination schema.Attribute, type_description string) schema.Schema {
+func GetExportSchema(ctx context.Context, destination schema.Attribute, type_description string) schema.Schema {
+	exportSchema := GetExportSharedSchema(ctx, type_description)
+	exportSchema.Attributes["destination"] = destination
+	return exportSchema
+}
+
+// GetExportSharedSchema returns the export schema without a destination, for resources that add their own.
+func GetExportSharedSchema(ctx context.Context, type_description string) schema.Schema {
 	return schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: type_description,
@@ -153,9 +169,17 @@ func GetExportSchema(destination schema.Attribute, type_description string) sche
 				Required:            true,
 			},
 			"schedule": schema.StringAttribute{
-				MarkdownDescription: "Export schedule (e.g., cron expression)",
-				Required:            true,
+				MarkdownDescription: "Export schedule as a five-field cron expression in UTC, e.g. `0 6 * * *`. Exactly one of `schedule` or `schedule_config` must be set. When `schedule_config` is set, this is the compiled cron expression.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					validators.Cron(),
+				},
+				PlanModifiers: []planmodifier.String{
+					ScheduleFromConfig(),
+				},
 			},
+			"schedule_config": scheduleConfigSchema(),
 			"notes": schema.StringAttribute{
 				MarkdownDescription: "Export notes that can be seen in the Funnel app",
 				Optional:            true,
@@ -174,8 +198,9 @@ func GetExportSchema(destination schema.Attribute, type_description string) sche
 							Optional:            true,
 						},
 						"type": schema.StringAttribute{
-							MarkdownDescription: "Field type fetched from data source export_field",
+							MarkdownDescription: "Field type fetched from data source export_field. If not set, the type of the workspace field is used",
 							Optional:            true,
+							Computed:            true,
 						},
 						"export_name": schema.StringAttribute{
 							MarkdownDescription: "Export column name (optional override)",
@@ -188,8 +213,9 @@ func GetExportSchema(destination schema.Attribute, type_description string) sche
 					},
 				},
 			},
+			"filter": exportFilterSchema(),
 			"filters": schema.ListNestedAttribute{
-				MarkdownDescription: "Export filters",
+				MarkdownDescription: "Export filters as a list of conditions that must all match. Conflicts with `filter` and `where_json`.",
 				Optional:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -224,6 +250,16 @@ func GetExportSchema(destination schema.Attribute, type_description string) sche
 					},
 				},
 			},
+			"where_json": schema.StringAttribute{
+				MarkdownDescription: "Export filter as a raw Funnel Meld JSON object sent as is, e.g. from `jsonencode`, for filters that `filter` can't represent. Read back into it when neither `filters` nor `filter` can represent the filter. Conflicts with `filters` and `filter`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.JSONSemanticEqual(),
+				},
+			},
 			"format": schema.SingleNestedAttribute{
 				MarkdownDescription: "Export format",
 				Required:            true,
@@ -310,7 +346,6 @@ func GetExportSchema(destination schema.Attribute, type_description string) sche
 					},
 				},
 			},
-			"destination": destination,
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Export name",
 				Required:            true,
@@ -321,5 +356,8 @@ func GetExportSchema(destination schema.Attribute, type_description string) sche
 				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
@@ -1,6 +1,21 @@
 package common
 
-import "strings"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 // ConvertFiltersToMeld converts the Terraform filter representation to the Funnel Meld format.
 func ConvertFiltersToMeld(filters []ExportFilterJSON) map[string]any {
@@ -42,11 +57,14 @@ func buildToMeldFieldCondition(filter ExportFilterJSON) map[string]any {
 }
 
 // ConvertFiltersFromMeld converts the Funnel Meld format to the Terraform filter representation.
+// It returns nil when the filters list can't represent the Meld filter, which the filter attribute may.
 func ConvertFiltersFromMeld(filters map[string]any) []ExportFilterJSON {
 	var andList []any
 
 	if val, ok := filters["=and"]; ok {
-		andList = val.([]any)
+		if andList, ok = val.([]any); !ok || len(filters) != 1 {
+			return nil
+		}
 	} else {
 		andList = []any{filters}
 	}
@@ -55,16 +73,19 @@ func ConvertFiltersFromMeld(filters map[string]any) []ExportFilterJSON {
 	for _, condition := range andList {
 		conditionMap, ok := condition.(map[string]any)
 		if !ok {
-			continue
+			return nil
 		}
 
-		for fieldId, fieldCondition := range conditionMap {
-			fieldConditionMap, ok := fieldCondition.(map[string]any)
-			if !ok {
-				continue
+		for _, fieldId := range slices.Sorted(maps.Keys(conditionMap)) {
+			fieldConditionMap, ok := conditionMap[fieldId].(map[string]any)
+			if !ok || strings.HasPrefix(fieldId, "=") {
+				return nil
 			}
 
-			filter := buildFromMeldFieldCondition(fieldId, fieldConditionMap)
+			filter, ok := buildFromMeldFieldCondition(fieldId, fieldConditionMap)
+			if !ok {
+				return nil
+			}
 			result = append(result, filter)
 		}
 	}
@@ -73,27 +94,663 @@ func ConvertFiltersFromMeld(filters map[string]any) []ExportFilterJSON {
 }
 
 // When converting from Meld, handle that fields can contain an array of OR statements or a single operation and value.
-func buildFromMeldFieldCondition(fieldId string, conditions map[string]any) ExportFilterJSON {
+// Anything else, like NOT or a list of values, can't be represented by the filters list.
+func buildFromMeldFieldCondition(fieldId string, conditions map[string]any) (ExportFilterJSON, bool) {
 	filter := ExportFilterJSON{FieldId: fieldId}
+	if len(conditions) != 1 {
+		return filter, false
+	}
 
 	if orList, hasOr := conditions["=or"].([]any); hasOr {
 		for _, item := range orList {
-			if itemMap, ok := item.(map[string]any); ok {
-				for key, val := range itemMap {
-					op := strings.TrimPrefix(key, "=")
-					filter.Or = append(filter.Or, ExportFilterOrJSON{Operation: op, Value: val.(string)})
+			itemMap, ok := item.(map[string]any)
+			if !ok || len(itemMap) != 1 {
+				return filter, false
+			}
+			for key, val := range itemMap {
+				op, isOp := strings.CutPrefix(key, "=")
+				value, isString := val.(string)
+				if !isOp || !isString || op == "and" || op == "or" || op == "not" {
+					return filter, false
 				}
+				filter.Or = append(filter.Or, ExportFilterOrJSON{Operation: op, Value: value})
 			}
 		}
-	} else {
-		for key, val := range conditions {
-			if after, ok := strings.CutPrefix(key, "="); ok {
-				filter.Operation = after
-				filter.Value = val.(string)
-				break
+		return filter, true
+	}
+
+	for key, val := range conditions {
+		op, isOp := strings.CutPrefix(key, "=")
+		value, isString := val.(string)
+		if !isOp || !isString || op == "and" || op == "or" || op == "not" {
+			return filter, false
+		}
+		filter.Operation = op
+		filter.Value = value
+	}
+
+	return filter, true
+}
+
+// Filter operations that compare numbers when the value is a number, and the operation that takes a list of values.
+var numericFilterOperations = []string{"gt", "gte", "lt", "lte"}
+
+const inFilterOperation = "in"
+
+// ExportFilterCondition is a condition on a field, the innermost level of a filter expression.
+type ExportFilterCondition struct {
+	FieldId   types.String   `tfsdk:"field_id"`
+	Operation types.String   `tfsdk:"operation"`
+	Value     types.String   `tfsdk:"value"`
+	Values    []types.String `tfsdk:"values"`
+	Not       types.Bool     `tfsdk:"not"`
+}
+
+// ExportFilterGroup is a condition or a group of conditions in a filter expression.
+type ExportFilterGroup struct {
+	ExportFilterCondition
+	And []ExportFilterCondition `tfsdk:"and"`
+	Or  []ExportFilterCondition `tfsdk:"or"`
+}
+
+// ExportFilterExpression is the filter attribute: a condition or a group of conditions and groups.
+// Terraform schemas can't be recursive, so the expression nests three levels. With not on every level that's
+// enough for any boolean expression, e.g. as an OR of ANDs.
+type ExportFilterExpression struct {
+	ExportFilterCondition
+	And []ExportFilterGroup `tfsdk:"and"`
+	Or  []ExportFilterGroup `tfsdk:"or"`
+}
+
+// ExportFilterExpressionJSON is a filter expression of any depth, between the Terraform and the Meld representation.
+type ExportFilterExpressionJSON struct {
+	FieldId   string                       `json:"field_id,omitempty"`
+	Operation string                       `json:"operation,omitempty"`
+	Value     string                       `json:"value,omitempty"`
+	Values    []string                     `json:"values,omitempty"`
+	Not       bool                         `json:"not,omitempty"`
+	And       []ExportFilterExpressionJSON `json:"and,omitempty"`
+	Or        []ExportFilterExpressionJSON `json:"or,omitempty"`
+}
+
+// exportFilterLevels is how deep the filter attribute nests: the filter, its groups and their conditions.
+const exportFilterLevels = 3
+
+func exportFilterSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Export filter as a boolean expression of conditions on fields, nested up to three levels. Conflicts with `filters` and `where_json`.",
+		Optional:            true,
+		Validators:          []validator.Object{filterExpressionValidator{}},
+		Attributes:          exportFilterAttributes(exportFilterLevels),
+	}
+}
+
+// exportFilterAttributes returns the attributes of a level of the filter expression, with and and or lists of the
+// next level unless it's the innermost.
+func exportFilterAttributes(levels int) map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"field_id": schema.StringAttribute{
+			MarkdownDescription: "Field ID to filter on. Exactly one of `field_id`, `and` and `or` must be set",
+			Optional:            true,
+		},
+		"operation": schema.StringAttribute{
+			MarkdownDescription: "Filter operation, e.g. `eq`, `contains`, `in` or `gt`. Required with `field_id`",
+			Optional:            true,
+		},
+		"value": schema.StringAttribute{
+			MarkdownDescription: "Value to filter by. `gt`, `gte`, `lt` and `lte` compare numeric values as numbers, which must be written in their shortest form like `100.5`",
+			Optional:            true,
+		},
+		"values": schema.ListAttribute{
+			MarkdownDescription: "Values to filter by with the `in` operation",
+			ElementType:         types.StringType,
+			Optional:            true,
+		},
+		"not": schema.BoolAttribute{
+			MarkdownDescription: "Whether to negate the condition or group",
+			Optional:            true,
+		},
+	}
+	if levels == 1 {
+		return attributes
+	}
+
+	members := "Conditions or groups"
+	if levels == 2 {
+		members = "Conditions"
+	}
+	for name, match := range map[string]string{"and": "all", "or": "at least one"} {
+		attributes[name] = schema.ListNestedAttribute{
+			MarkdownDescription: fmt.Sprintf("%s of which %s must match", members, match),
+			Optional:            true,
+			Validators:          []validator.List{listvalidator.SizeAtLeast(1)},
+			NestedObject: schema.NestedAttributeObject{
+				Validators: []validator.Object{filterExpressionValidator{}},
+				Attributes: exportFilterAttributes(levels - 1),
+			},
+		}
+	}
+
+	return attributes
+}
+
+// filterExpressionValidator checks that a level of the filter expression is either a complete condition or a group.
+type filterExpressionValidator struct{}
+
+func (v filterExpressionValidator) Description(ctx context.Context) string {
+	return "exactly one of field_id, and and or must be set, and a condition needs an operation with a value, or values for in"
+}
+
+func (v filterExpressionValidator) MarkdownDescription(ctx context.Context) string {
+	return "exactly one of `field_id`, `and` and `or` must be set, and a condition needs an `operation` with a `value`, or `values` for `in`"
+}
+
+func (v filterExpressionValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// The innermost level has no and or or, which is the same as them being null.
+	attributes := req.ConfigValue.Attributes()
+	for _, value := range attributes {
+		if value.IsUnknown() {
+			return
+		}
+	}
+	isSet := func(name string) bool {
+		value, ok := attributes[name]
+		return ok && !value.IsNull()
+	}
+
+	var set []string
+	for _, name := range []string{"field_id", "and", "or"} {
+		if isSet(name) {
+			set = append(set, name)
+		}
+	}
+	if len(set) != 1 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Filter Expression",
+			fmt.Sprintf("Exactly one of field_id, and and or must be set, got %d.", len(set)),
+		)
+		return
+	}
+
+	if !isSet("field_id") {
+		for _, name := range []string{"operation", "value", "values"} {
+			if isSet(name) {
+				resp.Diagnostics.AddAttributeError(req.Path.AtName(name), "Invalid Filter Expression", name+" can only be set with field_id.")
 			}
 		}
+		return
+	}
+
+	operation, _ := attributes["operation"].(types.String)
+	switch {
+	case operation.IsNull():
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("operation"), "Invalid Filter Expression", "operation is required with field_id.")
+	case operation.ValueString() == inFilterOperation && (!isSet("values") || isSet("value")):
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("values"), "Invalid Filter Expression", "The in operation takes values instead of value.")
+	case operation.ValueString() != inFilterOperation && (!isSet("value") || isSet("values")):
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("value"),
+			"Invalid Filter Expression",
+			fmt.Sprintf("The %s operation takes a value, values is only for the in operation.", operation.ValueString()),
+		)
+	case slices.Contains(numericFilterOperations, operation.ValueString()):
+		// The number is sent as a float and read back in its shortest form, so any other form would always show a diff
+		value, _ := attributes["value"].(types.String)
+		if number, err := strconv.ParseFloat(value.ValueString(), 64); err == nil {
+			if canonical := strconv.FormatFloat(number, 'f', -1, 64); canonical != value.ValueString() {
+				resp.Diagnostics.AddAttributeError(
+					req.Path.AtName("value"),
+					"Invalid Filter Expression",
+					fmt.Sprintf("The number %q must be written as %q.", value.ValueString(), canonical),
+				)
+			}
+		}
+	}
+}
+
+// ConvertWhereToMeld converts the where_json, filter or filters attribute of the export, whichever is set, to the Funnel Meld format.
+// The filters list is passed converted to JSON.
+func ConvertWhereToMeld(filters []ExportFilterJSON, model ExportShared) (map[string]any, error) {
+	switch {
+	case !model.WhereJSON.IsNull() && !model.WhereJSON.IsUnknown():
+		return parseWhereJSON(model.WhereJSON.ValueString())
+	case model.Filter != nil:
+		return ConvertFilterExpressionToMeld(model.Filter.toJSON()), nil
+	default:
+		return ConvertFiltersToMeld(filters), nil
+	}
+}
+
+// parseWhereJSON parses the where_json attribute to send it as is. Numbers are kept as written instead of as floats.
+func parseWhereJSON(whereJSON string) (map[string]any, error) {
+	decoder := json.NewDecoder(strings.NewReader(whereJSON))
+	decoder.UseNumber()
+
+	var where map[string]any
+	if err := decoder.Decode(&where); err != nil {
+		return nil, fmt.Errorf("where_json is not a JSON object: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("where_json has data after the JSON object")
+	}
+	return where, nil
+}
+
+// ConvertWhereToJSON converts the Funnel Meld format to the where_json attribute, or null when there is no filter.
+func ConvertWhereToJSON(where map[string]any) types.String {
+	if len(where) == 0 {
+		return types.StringNull()
+	}
+
+	whereJSON, err := json.Marshal(where)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(string(whereJSON))
+}
+
+// ConvertFilterFromMeld converts the Funnel Meld format to the filter attribute.
+// It returns nil when there is no filter or when the filter nests deeper than the filter attribute.
+func ConvertFilterFromMeld(where map[string]any) *ExportFilterExpression {
+	if len(where) == 0 {
+		return nil
+	}
+
+	expression, err := ConvertFilterExpressionFromMeld(where)
+	if err != nil {
+		return nil
+	}
+
+	filter, err := filterExpressionFromJSON(expression)
+	if err != nil {
+		return nil
+	}
+	return &filter
+}
+
+// ConvertFilterExpressionToMeld converts a filter expression to the Funnel Meld format.
+func ConvertFilterExpressionToMeld(expression ExportFilterExpressionJSON) map[string]any {
+	var meld map[string]any
+
+	switch {
+	case len(expression.And) > 0:
+		meld = map[string]any{"=and": filterExpressionsToMeld(expression.And)}
+	case len(expression.Or) > 0:
+		meld = map[string]any{"=or": filterExpressionsToMeld(expression.Or)}
+	default:
+		meld = map[string]any{
+			expression.FieldId: map[string]any{
+				"=" + expression.Operation: filterOperandToMeld(expression),
+			},
+		}
+	}
+
+	if expression.Not {
+		return map[string]any{"=not": meld}
 	}
+	return meld
+}
+
+func filterExpressionsToMeld(expressions []ExportFilterExpressionJSON) []any {
+	meld := make([]any, 0, len(expressions))
+	for _, expression := range expressions {
+		meld = append(meld, ConvertFilterExpressionToMeld(expression))
+	}
+	return meld
+}
+
+// filterOperandToMeld returns the values of the in operation as a list, and numbers compared by a numeric operation as numbers.
+func filterOperandToMeld(expression ExportFilterExpressionJSON) any {
+	if expression.Operation == inFilterOperation {
+		values := make([]any, 0, len(expression.Values))
+		for _, value := range expression.Values {
+			values = append(values, value)
+		}
+		return values
+	}
+
+	if slices.Contains(numericFilterOperations, expression.Operation) {
+		if number, err := strconv.ParseFloat(expression.Value, 64); err == nil {
+			return number
+		}
+	}
+
+	return expression.Value
+}
+
+// ConvertFilterExpressionFromMeld converts the Funnel Meld format to a filter expression.
+// Several fields in one object, or several operations on one field, are an AND of the conditions.
+func ConvertFilterExpressionFromMeld(meld map[string]any) (ExportFilterExpressionJSON, error) {
+	if len(meld) != 1 {
+		// Sort the keys for a stable order of the conditions.
+		var and []ExportFilterExpressionJSON
+		for _, key := range slices.Sorted(maps.Keys(meld)) {
+			expression, err := ConvertFilterExpressionFromMeld(map[string]any{key: meld[key]})
+			if err != nil {
+				return ExportFilterExpressionJSON{}, err
+			}
+			and = append(and, expression)
+		}
+		if len(and) == 0 {
+			return ExportFilterExpressionJSON{}, fmt.Errorf("empty filter")
+		}
+		return ExportFilterExpressionJSON{And: and}, nil
+	}
+
+	for key, value := range meld {
+		switch key {
+		case "=not":
+			inner, ok := value.(map[string]any)
+			if !ok {
+				return ExportFilterExpressionJSON{}, fmt.Errorf("=not takes an object, got %T", value)
+			}
+			expression, err := ConvertFilterExpressionFromMeld(inner)
+			if err != nil {
+				return ExportFilterExpressionJSON{}, err
+			}
+			expression.Not = !expression.Not
+			return expression, nil
+		case "=and", "=or":
+			expressions, err := filterExpressionsFromMeld(key, value)
+			if err != nil {
+				return ExportFilterExpressionJSON{}, err
+			}
+			if key == "=and" {
+				return ExportFilterExpressionJSON{And: expressions}, nil
+			}
+			return ExportFilterExpressionJSON{Or: expressions}, nil
+		default:
+			if strings.HasPrefix(key, "=") {
+				return ExportFilterExpressionJSON{}, fmt.Errorf("unsupported operator %s", key)
+			}
+			conditions, ok := value.(map[string]any)
+			if !ok {
+				return ExportFilterExpressionJSON{}, fmt.Errorf("the condition on %s is not an object", key)
+			}
+			return filterConditionFromMeld(key, conditions)
+		}
+	}
+
+	return ExportFilterExpressionJSON{}, nil
+}
+
+func filterExpressionsFromMeld(operator string, value any) ([]ExportFilterExpressionJSON, error) {
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("%s takes a list of conditions", operator)
+	}
+
+	expressions := make([]ExportFilterExpressionJSON, 0, len(list))
+	for _, item := range list {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s takes a list of conditions, got %T", operator, item)
+		}
+		expression, err := ConvertFilterExpressionFromMeld(itemMap)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, expression)
+	}
+	return expressions, nil
+}
+
+// filterConditionFromMeld converts the operations on a field, which the filters list writes as an OR of operations.
+func filterConditionFromMeld(fieldId string, conditions map[string]any) (ExportFilterExpressionJSON, error) {
+	if len(conditions) != 1 {
+		return ConvertFilterExpressionFromMeld(map[string]any{"=and": splitFieldConditions(fieldId, conditions)})
+	}
+
+	for key, operand := range conditions {
+		operation, ok := strings.CutPrefix(key, "=")
+		if !ok {
+			return ExportFilterExpressionJSON{}, fmt.Errorf("the condition on %s has no operation", fieldId)
+		}
+
+		switch operation {
+		case "not":
+			inner, ok := operand.(map[string]any)
+			if !ok {
+				return ExportFilterExpressionJSON{}, fmt.Errorf("=not on %s takes an object, got %T", fieldId, operand)
+			}
+			return ConvertFilterExpressionFromMeld(map[string]any{"=not": map[string]any{fieldId: inner}})
+		case "and", "or":
+			items, ok := operand.([]any)
+			if !ok {
+				return ExportFilterExpressionJSON{}, fmt.Errorf("=%s on %s takes a list of operations", operation, fieldId)
+			}
+			fieldConditions := make([]any, 0, len(items))
+			for _, item := range items {
+				fieldConditions = append(fieldConditions, map[string]any{fieldId: item})
+			}
+			return ConvertFilterExpressionFromMeld(map[string]any{key: fieldConditions})
+		}
+
+		expression := ExportFilterExpressionJSON{FieldId: fieldId, Operation: operation}
+		if operation == inFilterOperation {
+			list, ok := operand.([]any)
+			if !ok {
+				return ExportFilterExpressionJSON{}, fmt.Errorf("%s on %s takes a list of values, got %T", key, fieldId, operand)
+			}
+			for _, item := range list {
+				value, ok := item.(string)
+				if !ok {
+					return ExportFilterExpressionJSON{}, fmt.Errorf("%s on %s: unsupported value %v", key, fieldId, item)
+				}
+				expression.Values = append(expression.Values, value)
+			}
+			return expression, nil
+		}
+
+		value, err := filterValueFromMeld(operation, operand)
+		if err != nil {
+			return ExportFilterExpressionJSON{}, fmt.Errorf("%s on %s: %w", key, fieldId, err)
+		}
+		expression.Value = value
+		return expression, nil
+	}
+
+	return ExportFilterExpressionJSON{}, nil
+}
+
+// splitFieldConditions splits several operations on a field into a list of conditions with one operation each.
+func splitFieldConditions(fieldId string, conditions map[string]any) []any {
+	split := make([]any, 0, len(conditions))
+	for _, key := range slices.Sorted(maps.Keys(conditions)) {
+		split = append(split, map[string]any{fieldId: map[string]any{key: conditions[key]}})
+	}
+	return split
+}
+
+// filterValueFromMeld converts the value of an operation to the value attribute, which holds it as a string. Only values
+// that ConvertFilterExpressionToMeld sends back unchanged are converted: strings, and numbers of the numeric operations.
+func filterValueFromMeld(operation string, value any) (string, error) {
+	numeric := slices.Contains(numericFilterOperations, operation)
+
+	switch v := value.(type) {
+	case string:
+		// A numeric operation would send a number instead
+		if _, err := strconv.ParseFloat(v, 64); numeric && err == nil {
+			return "", fmt.Errorf("the number %q is a string", v)
+		}
+		return v, nil
+	case float64:
+		if !numeric {
+			return "", fmt.Errorf("unsupported number %v", v)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+func (c ExportFilterCondition) toJSON() ExportFilterExpressionJSON {
+	expression := ExportFilterExpressionJSON{
+		FieldId:   c.FieldId.ValueString(),
+		Operation: c.Operation.ValueString(),
+		Value:     c.Value.ValueString(),
+		Not:       c.Not.ValueBool(),
+	}
+	for _, value := range c.Values {
+		expression.Values = append(expression.Values, value.ValueString())
+	}
+	return expression
+}
+
+func (g ExportFilterGroup) toJSON() ExportFilterExpressionJSON {
+	expression := g.ExportFilterCondition.toJSON()
+	for _, condition := range g.And {
+		expression.And = append(expression.And, condition.toJSON())
+	}
+	for _, condition := range g.Or {
+		expression.Or = append(expression.Or, condition.toJSON())
+	}
+	return expression
+}
+
+func (e ExportFilterExpression) toJSON() ExportFilterExpressionJSON {
+	expression := e.ExportFilterCondition.toJSON()
+	for _, group := range e.And {
+		expression.And = append(expression.And, group.toJSON())
+	}
+	for _, group := range e.Or {
+		expression.Or = append(expression.Or, group.toJSON())
+	}
+	return expression
+}
+
+// filterConditionFromJSON converts the innermost level of the filter expression, which can't have and or or.
+func filterConditionFromJSON(expression ExportFilterExpressionJSON) (ExportFilterCondition, error) {
+	if len(expression.And) > 0 || len(expression.Or) > 0 {
+		return ExportFilterCondition{}, fmt.Errorf("the filter nests deeper than three levels")
+	}
+	return filterConditionAttributes(expression), nil
+}
+
+// filterConditionAttributes converts the condition attributes of any level, with unset attributes null like in the configuration.
+func filterConditionAttributes(expression ExportFilterExpressionJSON) ExportFilterCondition {
+	condition := ExportFilterCondition{
+		FieldId:   types.StringNull(),
+		Operation: types.StringNull(),
+		Value:     types.StringNull(),
+		Not:       types.BoolNull(),
+	}
+	if expression.FieldId != "" {
+		condition.FieldId = types.StringValue(expression.FieldId)
+		condition.Operation = types.StringValue(expression.Operation)
+		if expression.Operation != inFilterOperation {
+			condition.Value = types.StringValue(expression.Value)
+		}
+	}
+	for _, value := range expression.Values {
+		condition.Values = append(condition.Values, types.StringValue(value))
+	}
+	if expression.Not {
+		condition.Not = types.BoolValue(true)
+	}
+	return condition
+}
+
+func filterGroupFromJSON(expression ExportFilterExpressionJSON) (ExportFilterGroup, error) {
+	group := ExportFilterGroup{ExportFilterCondition: filterConditionAttributes(expression)}
+	for _, item := range expression.And {
+		condition, err := filterConditionFromJSON(item)
+		if err != nil {
+			return ExportFilterGroup{}, err
+		}
+		group.And = append(group.And, condition)
+	}
+	for _, item := range expression.Or {
+		condition, err := filterConditionFromJSON(item)
+		if err != nil {
+			return ExportFilterGroup{}, err
+		}
+		group.Or = append(group.Or, condition)
+	}
+	return group, nil
+}
+
+func filterExpressionFromJSON(expression ExportFilterExpressionJSON) (ExportFilterExpression, error) {
+	filter := ExportFilterExpression{ExportFilterCondition: filterConditionAttributes(expression)}
+	for _, item := range expression.And {
+		group, err := filterGroupFromJSON(item)
+		if err != nil {
+			return ExportFilterExpression{}, err
+		}
+		filter.And = append(filter.And, group)
+	}
+	for _, item := range expression.Or {
+		group, err := filterGroupFromJSON(item)
+		if err != nil {
+			return ExportFilterExpression{}, err
+		}
+		filter.Or = append(filter.Or, group)
+	}
+	return filter, nil
+}
+
+// SetFiltersFromMeld sets the filter attribute and where_json from the Funnel Meld format of the export read from Funnel.
+// Read and ImportState keep one of them and the filters list with KeepFilterRepresentation.
+func (e *ExportShared) SetFiltersFromMeld(where map[string]any) {
+	e.Filter = ConvertFilterFromMeld(where)
+	e.WhereJSON = ConvertWhereToJSON(where)
+}
+
+// KeepFilterRepresentation keeps one of the filters list, the filter attribute and where_json read from Funnel, since all of them
+// represent the same Meld filter: the one the prior state has, otherwise the filters list or the filter attribute when they can
+// represent the filter, and where_json when neither can. Pass an empty prior without a prior state, like on import.
+// It warns when the representation of the prior state can't represent the filter, which the next apply then replaces.
+func (e *ExportShared) KeepFilterRepresentation(prior ExportShared) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	filters, filter, whereJSON := e.Filters, e.Filter, e.WhereJSON
+	e.Filters, e.Filter, e.WhereJSON = nil, nil, types.StringNull()
+	hasWhere := !whereJSON.IsNull()
+
+	// An empty list is also what the filters list reads as when it can't represent the filter
+	switch {
+	case !prior.WhereJSON.IsNull():
+		e.WhereJSON = whereJSON
+	case prior.Filter != nil:
+		e.Filter = filter
+		if hasWhere && filter == nil {
+			diags.Append(unrepresentableFilterWarning("filter", whereJSON))
+		}
+	case len(prior.Filters) > 0:
+		e.Filters = filters
+		if hasWhere && len(filters) == 0 {
+			diags.Append(unrepresentableFilterWarning("filters", whereJSON))
+		}
+	case len(filters) > 0:
+		e.Filters = filters
+	case filter != nil:
+		e.Filter = filter
+	case hasWhere:
+		e.WhereJSON = whereJSON
+		diags.AddAttributeWarning(
+			path.Root("where_json"),
+			"Export Filter Read as where_json",
+			"The filter of the export in Funnel can't be represented by the filters or filter attributes, so it's read into where_json.",
+		)
+	}
+
+	return diags
+}
 
-	return filter
+func unrepresentableFilterWarning(attribute string, whereJSON types.String) diag.Diagnostic {
+	return diag.NewAttributeWarningDiagnostic(
+		path.Root(attribute),
+		"Export Filter Can't Be Represented",
+		fmt.Sprintf(
+			"The filter of the export in Funnel can't be represented by the %s attribute, for example after it was edited in the Funnel app. "+
+				"It's left out of the state, so the next apply replaces it with the configured %s. "+
+				"To keep it, set where_json to the Meld filter instead:\n\n%s",
+			attribute, attribute, whereJSON.ValueString(),
+		),
+	)
 }
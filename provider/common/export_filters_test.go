@@ -1,8 +1,15 @@
 package common
 
 import (
+	"context"
+	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestConvertFiltersToMeld_WithMixedFilters(t *testing.T) {
@@ -154,3 +161,434 @@ func TestConvertFiltersFromMeld_EmptyFilters(t *testing.T) {
 		t.Errorf("Expected empty slice for empty filters, got %v", result)
 	}
 }
+
+func TestConvertFiltersFromMeld_NotRepresentable(t *testing.T) {
+	for name, filters := range map[string]map[string]any{
+		"or across fields": {"=or": []any{
+			map[string]any{"source": map[string]any{"=eq": "adwords"}},
+			map[string]any{"campaign": map[string]any{"=contains": "brand"}},
+		}},
+		"not":    {"=not": map[string]any{"source": map[string]any{"=eq": "adwords"}}},
+		"in":     {"source": map[string]any{"=in": []any{"adwords", "facebook"}}},
+		"number": {"spend": map[string]any{"=gt": 100.0}},
+	} {
+		if result := ConvertFiltersFromMeld(filters); result != nil {
+			t.Errorf("%s: expected nil, got %v", name, result)
+		}
+	}
+}
+
+// filterCondition is a condition of the filter attribute as Terraform reads it from the configuration.
+func filterCondition(fieldId string, operation string, value string) ExportFilterCondition {
+	return ExportFilterCondition{FieldId: types.StringValue(fieldId), Operation: types.StringValue(operation), Value: types.StringValue(value)}
+}
+
+func TestConvertWhereToMeld_Filter_ReadBack(t *testing.T) {
+	filter := &ExportFilterExpression{
+		Or: []ExportFilterGroup{
+			{ExportFilterCondition: filterCondition("sourceType", "eq", "adwords")},
+			{ExportFilterCondition: filterCondition("cost", "gt", "100")},
+		},
+	}
+
+	where, err := ConvertWhereToMeld(nil, ExportShared{Filter: filter})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := where["=or"]; !ok {
+		t.Errorf("Expected the filter to be sent as an OR, got %v", where)
+	}
+
+	// The filters list can't represent a number, so the import reads the filter attribute
+	var read ExportShared
+	read.SetFiltersFromMeld(meldRoundTrip(t, where))
+	if diags := read.KeepFilterRepresentation(ExportShared{}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !reflect.DeepEqual(read.Filter, filter) || !read.WhereJSON.IsNull() {
+		t.Errorf("Expected only the filter %+v, got %+v and %v", filter, read.Filter, read.WhereJSON)
+	}
+}
+
+func TestConvertWhereToMeld_WhereJSON_ReadBack(t *testing.T) {
+	whereJSON := `{"=or":[{"=and":[{"=or":[{"sourceType":{"=eq":"adwords"}},{"cost":{"=gt":100}}]}]}]}`
+
+	where, err := ConvertWhereToMeld(nil, ExportShared{WhereJSON: types.StringValue(whereJSON)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The filter is nested deeper than the filter attribute allows, so the import reads it into where_json
+	var read ExportShared
+	read.SetFiltersFromMeld(meldRoundTrip(t, where))
+	if diags := read.KeepFilterRepresentation(ExportShared{}); diags.WarningsCount() != 1 {
+		t.Errorf("Expected a warning, got %v", diags)
+	}
+	if read.Filters != nil || read.Filter != nil || read.WhereJSON.ValueString() != whereJSON {
+		t.Errorf("Expected only where_json, got %v, %+v and %v", read.Filters, read.Filter, read.WhereJSON)
+	}
+}
+
+// meldRoundTrip sends the Meld filter through JSON, like the Funnel API.
+func meldRoundTrip(t *testing.T, meld map[string]any) map[string]any {
+	t.Helper()
+	encoded, err := json.Marshal(meld)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestConvertFilterToMeld_NestedGroups(t *testing.T) {
+	// Source = Google Ads AND (Campaign contains 'brand' OR Spend > 100)
+	filter := ExportFilterExpression{
+		And: []ExportFilterGroup{
+			{ExportFilterCondition: filterCondition("sourceType", "eq", "adwords")},
+			{Or: []ExportFilterCondition{
+				filterCondition("campaign", "contains", "brand"),
+				filterCondition("spend", "gt", "100"),
+			}},
+		},
+	}
+
+	result, err := ConvertWhereToMeld(nil, ExportShared{Filter: &filter})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"=and": []any{
+			map[string]any{"sourceType": map[string]any{"=eq": "adwords"}},
+			map[string]any{"=or": []any{
+				map[string]any{"campaign": map[string]any{"=contains": "brand"}},
+				map[string]any{"spend": map[string]any{"=gt": 100.0}},
+			}},
+		},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestConvertWhereToMeld_FiltersWithoutFilter(t *testing.T) {
+	filters := []ExportFilterJSON{{FieldId: "status_field", Operation: "equals", Value: "active"}}
+
+	if result, err := ConvertWhereToMeld(filters, ExportShared{}); err != nil || !reflect.DeepEqual(result, ConvertFiltersToMeld(filters)) {
+		t.Errorf("Expected the filters list, got %v and %v", result, err)
+	}
+}
+
+func TestConvertWhereToMeld_WhereJSON(t *testing.T) {
+	whereJSON := `{"=and": [{"=not": {"sourceType": {"=in": ["adwords", "facebookads"]}}}, {"cost": {"=gt": 100.10}}]}`
+
+	result, err := ConvertWhereToMeld(nil, ExportShared{WhereJSON: types.StringValue(whereJSON)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"=and": []any{
+			map[string]any{"=not": map[string]any{"sourceType": map[string]any{"=in": []any{"adwords", "facebookads"}}}},
+			map[string]any{"cost": map[string]any{"=gt": json.Number("100.10")}},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// Sent as is and read back into where_json
+	sent, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(sent) != `{"=and":[{"=not":{"sourceType":{"=in":["adwords","facebookads"]}}},{"cost":{"=gt":100.10}}]}` {
+		t.Errorf("Expected the where_json to be sent as is, got %s", sent)
+	}
+	if read := ConvertWhereToJSON(meldRoundTrip(t, result)); read.ValueString() != `{"=and":[{"=not":{"sourceType":{"=in":["adwords","facebookads"]}}},{"cost":{"=gt":100.1}}]}` {
+		t.Errorf("Expected the where read back, got %v", read)
+	}
+}
+
+func TestConvertWhereToMeld_InvalidWhereJSON(t *testing.T) {
+	for _, whereJSON := range []string{`["=and"]`, `{"=and": `, `{} {}`} {
+		if _, err := ConvertWhereToMeld(nil, ExportShared{WhereJSON: types.StringValue(whereJSON)}); err == nil {
+			t.Errorf("%s: expected an error", whereJSON)
+		}
+	}
+}
+
+func TestConvertWhereToJSON_NoFilter(t *testing.T) {
+	if result := ConvertWhereToJSON(map[string]any{}); !result.IsNull() {
+		t.Errorf("Expected null, got %v", result)
+	}
+}
+
+func TestConvertFilter_RoundTrip(t *testing.T) {
+	tests := map[string]ExportFilterExpression{
+		"condition": {ExportFilterCondition: filterCondition("sourceType", "eq", "adwords")},
+		"negated condition": {ExportFilterCondition: ExportFilterCondition{
+			FieldId:   types.StringValue("campaign"),
+			Operation: types.StringValue("contains"),
+			Value:     types.StringValue("test"),
+			Not:       types.BoolValue(true),
+		}},
+		"in": {ExportFilterCondition: ExportFilterCondition{
+			FieldId:   types.StringValue("sourceType"),
+			Operation: types.StringValue("in"),
+			Values:    []types.String{types.StringValue("adwords"), types.StringValue("facebookads")},
+		}},
+		"nested groups": {
+			And: []ExportFilterGroup{
+				{ExportFilterCondition: filterCondition("sourceType", "eq", "adwords")},
+				{Or: []ExportFilterCondition{
+					filterCondition("campaign", "contains", "brand"),
+					filterCondition("spend", "gt", "100.5"),
+				}},
+			},
+		},
+		"negated groups": {
+			ExportFilterCondition: ExportFilterCondition{Not: types.BoolValue(true)},
+			Or: []ExportFilterGroup{
+				{
+					ExportFilterCondition: ExportFilterCondition{Not: types.BoolValue(true)},
+					And: []ExportFilterCondition{
+						filterCondition("date", "after", "2025-01-01"),
+						filterCondition("impressions", "lte", "0"),
+					},
+				},
+				{ExportFilterCondition: filterCondition("spend", "gte", "not a number")},
+			},
+		},
+	}
+
+	for name, filter := range tests {
+		t.Run(name, func(t *testing.T) {
+			where, err := ConvertWhereToMeld(nil, ExportShared{Filter: &filter})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			meld := meldRoundTrip(t, where)
+
+			result := ConvertFilterFromMeld(meld)
+			if result == nil || !reflect.DeepEqual(*result, filter) {
+				t.Errorf("Expected %+v, got %+v from %v", filter, result, meld)
+			}
+		})
+	}
+}
+
+func TestConvertFilterFromMeld_FiltersList(t *testing.T) {
+	filters := []ExportFilterJSON{
+		{FieldId: "brand_field", Or: []ExportFilterOrJSON{{Operation: "contains", Value: "burger king"}, {Operation: "notcontains", Value: "wendys"}}},
+		{FieldId: "date_field", Operation: "after", Value: "2025"},
+	}
+
+	result := ConvertFilterFromMeld(meldRoundTrip(t, ConvertFiltersToMeld(filters)))
+
+	expected := ExportFilterExpression{
+		And: []ExportFilterGroup{
+			{Or: []ExportFilterCondition{
+				filterCondition("brand_field", "contains", "burger king"),
+				filterCondition("brand_field", "notcontains", "wendys"),
+			}},
+			{ExportFilterCondition: filterCondition("date_field", "after", "2025")},
+		},
+	}
+	if result == nil || !reflect.DeepEqual(*result, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+}
+
+func TestConvertFilterFromMeld_NotRepresentable(t *testing.T) {
+	condition := map[string]any{"spend": map[string]any{"=gt": 100.0}}
+	for name, where := range map[string]map[string]any{
+		"empty":        {},
+		"too deep":     {"=and": []any{map[string]any{"=or": []any{map[string]any{"=and": []any{condition}}}}}},
+		"unknown":      {"=xor": []any{condition}},
+		"empty and":    {"=and": []any{}},
+		"object value": {"spend": map[string]any{"=gt": map[string]any{}}},
+	} {
+		if result := ConvertFilterFromMeld(where); result != nil {
+			t.Errorf("%s: expected nil, got %+v", name, result)
+		}
+	}
+}
+
+func TestConvertFilterFromMeld_RoundTrip(t *testing.T) {
+	for name, where := range map[string]map[string]any{
+		"string":                      {"campaign": map[string]any{"=eq": "brand"}},
+		"number of numeric operation": {"cost": map[string]any{"=gt": 100.5}},
+		"string of numeric operation": {"date": map[string]any{"=gte": "2025-01-01"}},
+		"in":                          {"sourceType": map[string]any{"=in": []any{"adwords", "facebookads"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			filter := ConvertFilterFromMeld(meldRoundTrip(t, where))
+			if filter == nil {
+				t.Fatalf("Expected a filter for %v", where)
+			}
+
+			result, err := ConvertWhereToMeld(nil, ExportShared{Filter: filter})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(meldRoundTrip(t, result), meldRoundTrip(t, where)) {
+				t.Errorf("Expected %v to be sent back unchanged, got %v", where, result)
+			}
+		})
+	}
+}
+
+func TestConvertFilterFromMeld_ValuesTheFilterCantSendBack(t *testing.T) {
+	for name, where := range map[string]map[string]any{
+		"number of eq":                 {"=and": []any{map[string]any{"cost": map[string]any{"=eq": 5.0}}, map[string]any{"active": map[string]any{"=eq": true}}}},
+		"number":                       {"cost": map[string]any{"=eq": 5.0}},
+		"boolean":                      {"active": map[string]any{"=eq": true}},
+		"boolean of numeric operation": {"cost": map[string]any{"=gt": true}},
+		"number string of numeric":     {"cost": map[string]any{"=gt": "100"}},
+		"number in list":               {"cost": map[string]any{"=in": []any{"adwords", 5.0}}},
+		"in without list":              {"sourceType": map[string]any{"=in": "adwords"}},
+		"list without in":              {"sourceType": map[string]any{"=eq": []any{"adwords"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if filter := ConvertFilterFromMeld(where); filter != nil {
+				t.Errorf("Expected nil, got %+v", filter)
+			}
+
+			// An import reads it into where_json instead
+			var read ExportShared
+			read.SetFiltersFromMeld(where)
+			if diags := read.KeepFilterRepresentation(ExportShared{}); diags.WarningsCount() != 1 || read.WhereJSON.IsNull() {
+				t.Errorf("Expected where_json with a warning, got %v and %v", read, diags)
+			}
+		})
+	}
+}
+
+func TestSetFiltersFromMeld(t *testing.T) {
+	var read ExportShared
+	read.SetFiltersFromMeld(map[string]any{"sourceType": map[string]any{"=eq": "adwords"}})
+
+	want := &ExportFilterExpression{ExportFilterCondition: filterCondition("sourceType", "eq", "adwords")}
+	if !reflect.DeepEqual(read.Filter, want) || read.WhereJSON.ValueString() != `{"sourceType":{"=eq":"adwords"}}` {
+		t.Errorf("Expected the filter and where_json of the Meld filter, got %+v and %v", read.Filter, read.WhereJSON)
+	}
+
+	read.SetFiltersFromMeld(nil)
+	if read.Filter != nil || !read.WhereJSON.IsNull() {
+		t.Errorf("Expected no filter, got %+v and %v", read.Filter, read.WhereJSON)
+	}
+}
+
+func TestKeepFilterRepresentation(t *testing.T) {
+	filters := []ExportFilter{{FieldId: types.StringValue("status_field")}}
+	filter := &ExportFilterExpression{ExportFilterCondition: filterCondition("status_field", "equals", "active")}
+	whereJSON := types.StringValue(`{"status_field":{"=equals":"active"}}`)
+
+	tests := []struct {
+		name          string
+		prior         ExportShared
+		read          ExportShared
+		wantFilters   bool
+		wantFilter    bool
+		wantWhereJSON bool
+		wantWarning   bool
+	}{
+		{name: "prior filters", prior: ExportShared{Filters: filters}, read: ExportShared{Filters: filters, Filter: filter, WhereJSON: whereJSON}, wantFilters: true},
+		{name: "prior filter", prior: ExportShared{Filter: filter}, read: ExportShared{Filters: filters, Filter: filter, WhereJSON: whereJSON}, wantFilter: true},
+		{name: "prior where_json", prior: ExportShared{WhereJSON: whereJSON}, read: ExportShared{Filters: filters, Filter: filter, WhereJSON: whereJSON}, wantWhereJSON: true},
+		{name: "prior filters can't represent", prior: ExportShared{Filters: filters}, read: ExportShared{Filters: []ExportFilter{}, WhereJSON: whereJSON}, wantFilters: true, wantWarning: true},
+		{name: "prior filter can't represent", prior: ExportShared{Filter: filter}, read: ExportShared{WhereJSON: whereJSON}, wantWarning: true},
+		{name: "prior filter removed", prior: ExportShared{Filter: filter}, read: ExportShared{}},
+		{name: "import", read: ExportShared{Filters: filters, Filter: filter, WhereJSON: whereJSON}, wantFilters: true},
+		{name: "import of a filter the list can't represent", read: ExportShared{Filter: filter, WhereJSON: whereJSON}, wantFilter: true},
+		{name: "import with an empty filters list", read: ExportShared{Filters: []ExportFilter{}, Filter: filter, WhereJSON: whereJSON}, wantFilter: true},
+		{name: "import of a filter only where_json can represent", read: ExportShared{Filters: []ExportFilter{}, WhereJSON: whereJSON}, wantWhereJSON: true, wantWarning: true},
+		{name: "import without a filter", read: ExportShared{Filters: []ExportFilter{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := tt.read.KeepFilterRepresentation(tt.prior)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if (diags.WarningsCount() > 0) != tt.wantWarning {
+				t.Errorf("expected warning %v, got %v", tt.wantWarning, diags)
+			}
+			if (tt.read.Filters != nil) != tt.wantFilters || (tt.read.Filter != nil) != tt.wantFilter || !tt.read.WhereJSON.IsNull() != tt.wantWhereJSON {
+				t.Errorf(
+					"expected filters %v, filter %v and where_json %v, got %v, %v and %v",
+					tt.wantFilters, tt.wantFilter, tt.wantWhereJSON, tt.read.Filters, tt.read.Filter, tt.read.WhereJSON,
+				)
+			}
+		})
+	}
+}
+
+func TestKeepFilterRepresentation_WarningSuggestsWhereJSON(t *testing.T) {
+	read := ExportShared{WhereJSON: types.StringValue(`{"=xor":[]}`)}
+
+	diags := read.KeepFilterRepresentation(ExportShared{Filter: &ExportFilterExpression{}})
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail(), "where_json") || !strings.Contains(diags[0].Detail(), `{"=xor":[]}`) {
+		t.Errorf("Expected a warning with the Meld filter for where_json, got %v", diags)
+	}
+}
+
+func TestFilterExpressionValidator(t *testing.T) {
+	ctx := context.Background()
+	attributeTypes := exportFilterSchema().GetType().(types.ObjectType).AttrTypes
+
+	tests := []struct {
+		name      string
+		filter    ExportFilterExpression
+		wantError bool
+	}{
+		{name: "condition", filter: ExportFilterExpression{ExportFilterCondition: filterCondition("sourceType", "eq", "adwords")}},
+		{name: "in", filter: ExportFilterExpression{ExportFilterCondition: ExportFilterCondition{
+			FieldId:   types.StringValue("sourceType"),
+			Operation: types.StringValue("in"),
+			Values:    []types.String{types.StringValue("adwords")},
+		}}},
+		{name: "group", filter: ExportFilterExpression{Or: []ExportFilterGroup{{ExportFilterCondition: filterCondition("sourceType", "eq", "adwords")}}}},
+		{name: "nothing", filter: ExportFilterExpression{}, wantError: true},
+		{
+			name: "condition and group",
+			filter: ExportFilterExpression{
+				ExportFilterCondition: filterCondition("sourceType", "eq", "adwords"),
+				And:                   []ExportFilterGroup{{ExportFilterCondition: filterCondition("sourceType", "eq", "adwords")}},
+			},
+			wantError: true,
+		},
+		{name: "no operation", filter: ExportFilterExpression{ExportFilterCondition: ExportFilterCondition{FieldId: types.StringValue("sourceType")}}, wantError: true},
+		{name: "no value", filter: ExportFilterExpression{ExportFilterCondition: ExportFilterCondition{FieldId: types.StringValue("sourceType"), Operation: types.StringValue("eq")}}, wantError: true},
+		{name: "in with value", filter: ExportFilterExpression{ExportFilterCondition: filterCondition("sourceType", "in", "adwords")}, wantError: true},
+		{name: "number", filter: ExportFilterExpression{ExportFilterCondition: filterCondition("cost", "gt", "100.5")}, wantError: false},
+		{name: "number with trailing zero", filter: ExportFilterExpression{ExportFilterCondition: filterCondition("cost", "gt", "100.50")}, wantError: true},
+		{name: "number with exponent", filter: ExportFilterExpression{ExportFilterCondition: filterCondition("cost", "lte", "1e3")}, wantError: true},
+		{name: "number compared as string", filter: ExportFilterExpression{ExportFilterCondition: filterCondition("cost", "eq", "1.0")}, wantError: false},
+		{name: "group with value", filter: ExportFilterExpression{
+			ExportFilterCondition: ExportFilterCondition{Value: types.StringValue("adwords")},
+			Or:                    []ExportFilterGroup{{ExportFilterCondition: filterCondition("sourceType", "eq", "adwords")}},
+		}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, diags := types.ObjectValueFrom(ctx, attributeTypes, tt.filter)
+			if diags.HasError() {
+				t.Fatalf("could not build the filter: %v", diags)
+			}
+
+			resp := &validator.ObjectResponse{}
+			filterExpressionValidator{}.ValidateObject(ctx, validator.ObjectRequest{Path: path.Root("filter"), ConfigValue: value}, resp)
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-funnel/provider/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Frequencies of the schedule_config attribute.
+const (
+	ScheduleHourly = "hourly"
+	ScheduleDaily  = "daily"
+	ScheduleWeekly = "weekly"
+)
+
+// Weekdays in cron order, Sunday is 0.
+var scheduleWeekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// ScheduleConfig is a structured alternative to the cron expression in the schedule attribute.
+type ScheduleConfig struct {
+	Frequency types.String `tfsdk:"frequency"`
+	Hour      types.Int64  `tfsdk:"hour"`
+	Minute    types.Int64  `tfsdk:"minute"`
+	Weekday   types.String `tfsdk:"weekday"`
+	Timezone  types.String `tfsdk:"timezone"`
+}
+
+func scheduleConfigSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Structured export schedule that is compiled to the cron expression in `schedule`. Conflicts with `schedule`.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"frequency": schema.StringAttribute{
+				MarkdownDescription: "How often the export runs. One of `hourly`, `daily` or `weekly`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ScheduleHourly, ScheduleDaily, ScheduleWeekly),
+				},
+			},
+			"hour": schema.Int64Attribute{
+				MarkdownDescription: "Hour of the day (0-23) the export runs. Required for `daily` and `weekly` schedules.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 23),
+				},
+			},
+			"minute": schema.Int64Attribute{
+				MarkdownDescription: "Minute of the hour (0-59) the export runs. Default `0`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 59),
+				},
+			},
+			"weekday": schema.StringAttribute{
+				MarkdownDescription: "Day of the week the export runs, e.g. `monday`. Required for `weekly` schedules.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(scheduleWeekdays...),
+				},
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "IANA time zone of `hour` and `minute`, e.g. `Asia/Tokyo`. Default `UTC`. Funnel runs schedules in UTC, so the time zone must have a fixed offset: time zones with daylight saving time, like `Europe/Stockholm`, are rejected.",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.TimeZone(),
+				},
+			},
+		},
+	}
+}
+
+// checkScheduleConfig returns the attribute and reason when the attributes don't fit the frequency.
+func checkScheduleConfig(config ScheduleConfig) (string, error) {
+	frequency := config.Frequency.ValueString()
+
+	switch {
+	case frequency == ScheduleHourly && !config.Hour.IsNull():
+		return "hour", fmt.Errorf("hour can't be set for an hourly schedule")
+	case frequency != ScheduleHourly && config.Hour.IsNull():
+		return "hour", fmt.Errorf("hour is required for a %s schedule", frequency)
+	case frequency == ScheduleWeekly && config.Weekday.IsNull():
+		return "weekday", fmt.Errorf("weekday is required for a weekly schedule")
+	case frequency != ScheduleWeekly && !config.Weekday.IsNull():
+		return "weekday", fmt.Errorf("weekday can only be set for a weekly schedule")
+	}
+
+	return "", nil
+}
+
+// CompileSchedule compiles a schedule config to a cron expression in UTC.
+// The time zone must have a fixed offset, which is taken at now.
+func CompileSchedule(config ScheduleConfig, now time.Time) (string, error) {
+	if attribute, err := checkScheduleConfig(config); err != nil {
+		return "", fmt.Errorf("%s: %w", attribute, err)
+	}
+
+	location := time.UTC
+	if !config.Timezone.IsNull() {
+		var err error
+		if location, err = time.LoadLocation(config.Timezone.ValueString()); err != nil {
+			return "", fmt.Errorf("timezone: %w", err)
+		}
+		// A cron expression in UTC can only follow a fixed offset
+		if err := validators.CheckFixedOffset(location, now); err != nil {
+			return "", fmt.Errorf("timezone: %w", err)
+		}
+	}
+	_, offsetSeconds := now.In(location).Zone()
+	offset := offsetSeconds / 60
+
+	minute := int(config.Minute.ValueInt64())
+	hour := int(config.Hour.ValueInt64())
+
+	// Shift the local time by the offset in minutes and wrap around the hour, day or week.
+	switch config.Frequency.ValueString() {
+	case ScheduleHourly:
+		m := modulo(minute-offset, 60)
+		return fmt.Sprintf("%d * * * *", m), nil
+	case ScheduleDaily:
+		t := modulo(hour*60+minute-offset, 24*60)
+		return fmt.Sprintf("%d %d * * *", t%60, t/60), nil
+	case ScheduleWeekly:
+		weekday := 0
+		for i, name := range scheduleWeekdays {
+			if name == config.Weekday.ValueString() {
+				weekday = i
+			}
+		}
+		t := modulo(weekday*24*60+hour*60+minute-offset, 7*24*60)
+		return fmt.Sprintf("%d %d * * %d", t%60, (t/60)%24, t/(24*60)), nil
+	default:
+		return "", fmt.Errorf("frequency: unknown frequency %q", config.Frequency.ValueString())
+	}
+}
+
+func modulo(a, b int) int {
+	return ((a % b) + b) % b
+}
+
+// KeepScheduleConfig keeps the schedule_config of the prior state in the export read from Funnel, since Funnel only
+// stores the compiled cron expression.
+func (e *ExportShared) KeepScheduleConfig(prior ExportShared) {
+	e.ScheduleConfig = prior.ScheduleConfig
+}
+
+type scheduleFromConfigModifier struct{}
+
+// ScheduleFromConfig plans the schedule attribute as the cron expression compiled from schedule_config when it is set.
+func ScheduleFromConfig() planmodifier.String {
+	return scheduleFromConfigModifier{}
+}
+
+func (m scheduleFromConfigModifier) Description(ctx context.Context) string {
+	return "Compiles schedule_config to the cron expression of the schedule"
+}
+
+func (m scheduleFromConfigModifier) MarkdownDescription(ctx context.Context) string {
+	return "Compiles `schedule_config` to the cron expression of the `schedule`"
+}
+
+func (m scheduleFromConfigModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var config *ScheduleConfig
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schedule_config"), &config)...)
+	if resp.Diagnostics.HasError() || config == nil {
+		return
+	}
+
+	if config.Frequency.IsUnknown() || config.Hour.IsUnknown() || config.Minute.IsUnknown() || config.Weekday.IsUnknown() || config.Timezone.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	schedule, err := CompileSchedule(*config, time.Now())
+	if err != nil {
+		// The schedule config validator reports the invalid attribute.
+		return
+	}
+
+	resp.PlanValue = types.StringValue(schedule)
+}
+
+type scheduleConfigValidator struct{}
+
+func (v scheduleConfigValidator) Description(ctx context.Context) string {
+	return "schedule_config attributes must fit the frequency"
+}
+
+func (v scheduleConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return "`schedule_config` attributes must fit the `frequency`"
+}
+
+func (v scheduleConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *ScheduleConfig
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schedule_config"), &config)...)
+	if resp.Diagnostics.HasError() || config == nil {
+		return
+	}
+
+	if config.Frequency.IsUnknown() || config.Hour.IsUnknown() || config.Weekday.IsUnknown() {
+		return
+	}
+
+	if attribute, err := checkScheduleConfig(*config); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("schedule_config").AtName(attribute), "Invalid Schedule Configuration", err.Error()+".")
+	}
+}
@@ -0,0 +1,184 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCompileSchedule(t *testing.T) {
+	winter := time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2026, time.July, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		config ScheduleConfig
+		now    time.Time
+		want   string
+	}{
+		{
+			name:   "hourly",
+			config: ScheduleConfig{Frequency: types.StringValue(ScheduleHourly), Minute: types.Int64Value(15)},
+			now:    winter,
+			want:   "15 * * * *",
+		},
+		{
+			name:   "hourly with half hour offset",
+			config: ScheduleConfig{Frequency: types.StringValue(ScheduleHourly), Minute: types.Int64Value(15), Timezone: types.StringValue("Asia/Kolkata")},
+			now:    winter,
+			want:   "45 * * * *",
+		},
+		{
+			name:   "daily in UTC",
+			config: ScheduleConfig{Frequency: types.StringValue(ScheduleDaily), Hour: types.Int64Value(6)},
+			now:    winter,
+			want:   "0 6 * * *",
+		},
+		{
+			name:   "daily east of UTC",
+			config: ScheduleConfig{Frequency: types.StringValue(ScheduleDaily), Hour: types.Int64Value(6), Minute: types.Int64Value(30), Timezone: types.StringValue("Africa/Lagos")},
+			now:    winter,
+			want:   "30 5 * * *",
+		},
+		{
+			name:   "daily in summer",
+			config: ScheduleConfig{Frequency: types.StringValue(ScheduleDaily), Hour: types.Int64Value(6), Minute: types.Int64Value(30), Timezone: types.StringValue("Africa/Lagos")},
+			now:    summer,
+			want:   "30 5 * * *",
+		},
+		{
+			name:   "daily across midnight",
+			config: ScheduleConfig{Frequency: types.StringValue(ScheduleDaily), Hour: types.Int64Value(20), Timezone: types.StringValue("America/Bogota")},
+			now:    winter,
+			want:   "0 1 * * *",
+		},
+		{
+			name:   "weekly",
+			config: ScheduleConfig{Frequency: types.StringValue(ScheduleWeekly), Hour: types.Int64Value(9), Weekday: types.StringValue("monday")},
+			now:    winter,
+			want:   "0 9 * * 1",
+		},
+		{
+			name:   "weekly to the previous day",
+			config: ScheduleConfig{Frequency: types.StringValue(ScheduleWeekly), Hour: types.Int64Value(0), Minute: types.Int64Value(30), Weekday: types.StringValue("sunday"), Timezone: types.StringValue("Asia/Tokyo")},
+			now:    winter,
+			want:   "30 15 * * 6",
+		},
+		{
+			name:   "weekly to the next day",
+			config: ScheduleConfig{Frequency: types.StringValue(ScheduleWeekly), Hour: types.Int64Value(22), Weekday: types.StringValue("saturday"), Timezone: types.StringValue("Pacific/Honolulu")},
+			now:    winter,
+			want:   "0 8 * * 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompileSchedule(tt.config, tt.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCompileSchedule_RejectsDaylightSavingTime(t *testing.T) {
+	for _, timezone := range []string{"Europe/Stockholm", "America/New_York", "Australia/Sydney"} {
+		config := ScheduleConfig{Frequency: types.StringValue(ScheduleDaily), Hour: types.Int64Value(6), Timezone: types.StringValue(timezone)}
+		if _, err := CompileSchedule(config, time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)); err == nil {
+			t.Errorf("%s: expected an error", timezone)
+		}
+	}
+}
+
+func TestExportConfigValidators_Schedule(t *testing.T) {
+	start := ExportRange{Start: types.StringValue("2024-01-01")}
+
+	tests := []struct {
+		name     string
+		shared   ExportShared
+		wantErr  bool
+		wantPath path.Path
+	}{
+		{
+			name:   "cron",
+			shared: ExportShared{Schedule: types.StringValue("0 6 * * *"), Range: start},
+		},
+		{
+			name:   "daily",
+			shared: ExportShared{ScheduleConfig: &ScheduleConfig{Frequency: types.StringValue(ScheduleDaily), Hour: types.Int64Value(6)}, Range: start},
+		},
+		{
+			name:    "neither",
+			shared:  ExportShared{Range: start},
+			wantErr: true,
+		},
+		{
+			name:     "both",
+			shared:   ExportShared{Schedule: types.StringValue("0 6 * * *"), ScheduleConfig: &ScheduleConfig{Frequency: types.StringValue(ScheduleHourly)}, Range: start},
+			wantErr:  true,
+			wantPath: path.Root("schedule"),
+		},
+		{
+			name:     "daily without hour",
+			shared:   ExportShared{ScheduleConfig: &ScheduleConfig{Frequency: types.StringValue(ScheduleDaily)}, Range: start},
+			wantErr:  true,
+			wantPath: path.Root("schedule_config").AtName("hour"),
+		},
+		{
+			name:   "fixed offset",
+			shared: ExportShared{ScheduleConfig: &ScheduleConfig{Frequency: types.StringValue(ScheduleDaily), Hour: types.Int64Value(6), Timezone: types.StringValue("Asia/Tokyo")}, Range: start},
+		},
+		{
+			name:     "hourly with hour",
+			shared:   ExportShared{ScheduleConfig: &ScheduleConfig{Frequency: types.StringValue(ScheduleHourly), Hour: types.Int64Value(6)}, Range: start},
+			wantErr:  true,
+			wantPath: path.Root("schedule_config").AtName("hour"),
+		},
+		{
+			name:     "weekly without weekday",
+			shared:   ExportShared{ScheduleConfig: &ScheduleConfig{Frequency: types.StringValue(ScheduleWeekly), Hour: types.Int64Value(6)}, Range: start},
+			wantErr:  true,
+			wantPath: path.Root("schedule_config").AtName("weekday"),
+		},
+		{
+			name:     "daily with weekday",
+			shared:   ExportShared{ScheduleConfig: &ScheduleConfig{Frequency: types.StringValue(ScheduleDaily), Hour: types.Int64Value(6), Weekday: types.StringValue("monday")}, Range: start},
+			wantErr:  true,
+			wantPath: path.Root("schedule_config").AtName("weekday"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateExportConfig(t, testExportModel{ExportShared: tt.shared})
+
+			if !tt.wantErr {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			if len(tt.wantPath.Steps()) == 0 {
+				if ok {
+					t.Errorf("expected an error without a path, got one on %s", withPath.Path())
+				}
+				return
+			}
+			if !ok || !withPath.Path().Equal(tt.wantPath) {
+				t.Errorf("expected an error on %s, got %v", tt.wantPath, diags.Errors()[0])
+			}
+		})
+	}
+}
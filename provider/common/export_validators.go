@@ -0,0 +1,93 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// ExportConfigValidators returns the cross-attribute rules shared by all export resources.
+func ExportConfigValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("schedule"),
+			path.MatchRoot("schedule_config"),
+		),
+		scheduleConfigValidator{},
+		ExactlyOneOfAttributes(path.Root("range"), "start", "rolling_start"),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("range").AtName("end"),
+			path.MatchRoot("range").AtName("rolling_end"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("filters"),
+			path.MatchRoot("filter"),
+			path.MatchRoot("where_json"),
+		),
+	}
+}
+
+// ExactlyOneOfAttributes returns a config validator that requires exactly one of the named attributes of the parent to be set.
+// Unlike resourcevalidator.ExactlyOneOf it reports a missing attribute on the parent path, so the diagnostic points at the block to fix.
+func ExactlyOneOfAttributes(parent path.Path, names ...string) resource.ConfigValidator {
+	return exactlyOneOfAttributesValidator{parent: parent, names: names}
+}
+
+type exactlyOneOfAttributesValidator struct {
+	parent path.Path
+	names  []string
+}
+
+func (v exactlyOneOfAttributesValidator) Description(ctx context.Context) string {
+	paths := make([]string, len(v.names))
+	for i, name := range v.names {
+		paths[i] = v.parent.AtName(name).String()
+	}
+	return fmt.Sprintf("Exactly one of %s must be set", strings.Join(paths, ", "))
+}
+
+func (v exactlyOneOfAttributesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v exactlyOneOfAttributesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var parent attr.Value
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.parent, &parent)...)
+	if resp.Diagnostics.HasError() || parent.IsNull() || parent.IsUnknown() {
+		return
+	}
+
+	var configured []path.Path
+	for _, name := range v.names {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.parent.AtName(name), &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// An unknown value may still turn out null or set, so the rule can't be checked yet.
+		if value.IsUnknown() {
+			return
+		}
+		if !value.IsNull() {
+			configured = append(configured, v.parent.AtName(name))
+		}
+	}
+
+	switch {
+	case len(configured) == 0:
+		resp.Diagnostics.AddAttributeError(v.parent, "Missing Attribute Configuration", v.Description(ctx)+".")
+	case len(configured) > 1:
+		for _, p := range configured[1:] {
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Invalid Attribute Combination",
+				fmt.Sprintf("%s. %s conflicts with %s.", v.Description(ctx), p, configured[0]),
+			)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type testExportModel struct {
+	Destination types.String `tfsdk:"destination"`
+	ExportShared
+}
+
+// validateExportConfig runs the shared export config validators against the model as configuration.
+func validateExportConfig(t *testing.T, model testExportModel) diag.Diagnostics {
+	t.Helper()
+	ctx := context.Background()
+
+	s := GetExportSchema(ctx, schema.StringAttribute{Optional: true}, "Test export")
+	model.Timeouts = NullTimeouts()
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("could not build configuration: %v", diags)
+	}
+
+	// Like the framework, give every validator its own response since some of them replace the diagnostics.
+	req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: plan.Raw}}
+	var diags diag.Diagnostics
+	for _, v := range ExportConfigValidators() {
+		resp := &resource.ValidateConfigResponse{}
+		v.ValidateResource(ctx, req, resp)
+		diags.Append(resp.Diagnostics...)
+	}
+	return diags
+}
+
+func TestExportConfigValidators_Range(t *testing.T) {
+	rolling := &RollingDate{Periods: types.Int64Value(7), Period: types.StringValue("days")}
+
+	tests := []struct {
+		name     string
+		exRange  ExportRange
+		wantPath path.Path
+	}{
+		{name: "start", exRange: ExportRange{Start: types.StringValue("2024-01-01")}},
+		{name: "rolling start", exRange: ExportRange{RollingStart: rolling, RollingEnd: rolling}},
+		{name: "neither start", exRange: ExportRange{}, wantPath: path.Root("range")},
+		{name: "both starts", exRange: ExportRange{Start: types.StringValue("2024-01-01"), RollingStart: rolling}, wantPath: path.Root("range").AtName("rolling_start")},
+		{name: "both ends", exRange: ExportRange{Start: types.StringValue("2024-01-01"), End: types.StringValue("2024-02-01"), RollingEnd: rolling}, wantPath: path.Root("range").AtName("end")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateExportConfig(t, testExportModel{ExportShared: ExportShared{Schedule: types.StringValue("0 6 * * *"), Range: tt.exRange}})
+
+			if len(tt.wantPath.Steps()) == 0 {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(tt.wantPath) {
+				t.Errorf("expected an error on %s, got %v", tt.wantPath, diags.Errors()[0])
+			}
+		})
+	}
+}
+
+func TestExportConfigValidators_FilterConflictsWithFilters(t *testing.T) {
+	model := testExportModel{ExportShared: ExportShared{
+		Schedule: types.StringValue("0 6 * * *"),
+		Range:    ExportRange{Start: types.StringValue("2024-01-01")},
+		Filters:  []ExportFilter{{FieldId: types.StringValue("status_field"), Operation: types.StringValue("equals"), Value: types.StringValue("active")}},
+		Filter:   &ExportFilterExpression{ExportFilterCondition: filterCondition("status_field", "equals", "active")},
+	}}
+
+	if diags := validateExportConfig(t, model); !diags.HasError() {
+		t.Error("expected an error for both filters and filter")
+	}
+
+	model.Filters = nil
+	if diags := validateExportConfig(t, model); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestExportConfigValidators_WhereJSONConflictsWithFilters(t *testing.T) {
+	whereJSON := types.StringValue(`{"status_field": {"=equals": "active"}}`)
+
+	tests := []struct {
+		name      string
+		shared    ExportShared
+		wantError bool
+	}{
+		{name: "where_json", shared: ExportShared{WhereJSON: whereJSON}},
+		{
+			name:      "where_json and filters",
+			shared:    ExportShared{WhereJSON: whereJSON, Filters: []ExportFilter{{FieldId: types.StringValue("status_field"), Operation: types.StringValue("equals"), Value: types.StringValue("active")}}},
+			wantError: true,
+		},
+		{
+			name:      "where_json and filter",
+			shared:    ExportShared{WhereJSON: whereJSON, Filter: &ExportFilterExpression{ExportFilterCondition: filterCondition("status_field", "equals", "active")}},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.shared.Schedule = types.StringValue("0 6 * * *")
+			tt.shared.Range = ExportRange{Start: types.StringValue("2024-01-01")}
+
+			if diags := validateExportConfig(t, testExportModel{ExportShared: tt.shared}); diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
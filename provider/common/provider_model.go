@@ -6,9 +6,33 @@ import (
 
 // FunnelProviderModel is the provider configuration model and is used across the provider
 type FunnelProviderModel struct {
-	Environment    types.String `tfsdk:"environment"`
-	SubscriptionId types.String `tfsdk:"subscription_id"`
-	ClientId       types.String `tfsdk:"client_id"`
-	ClientSecret   types.String `tfsdk:"client_secret"`
-	Token          string       `tfsdk:"-"`
+	Environment       types.String             `tfsdk:"environment"`
+	SubscriptionId    types.String             `tfsdk:"subscription_id"`
+	ClientId          types.String             `tfsdk:"client_id"`
+	ClientSecret      types.String             `tfsdk:"client_secret"`
+	Profile           types.String             `tfsdk:"profile"`
+	CredentialProcess types.String             `tfsdk:"credential_process"`
+	Auth              *FunnelProviderAuthModel `tfsdk:"auth"`
+	APIURL            types.String             `tfsdk:"api_url"`
+	TokenURL          types.String             `tfsdk:"token_url"`
+	Audience          types.String             `tfsdk:"audience"`
+	MaxRetries        types.Int64              `tfsdk:"max_retries"`
+	MaxBackoff        types.String             `tfsdk:"max_backoff"`
+	RequestTimeout    types.String             `tfsdk:"request_timeout"`
+	ProxyURL          types.String             `tfsdk:"proxy_url"`
+	CACertPEM         types.String             `tfsdk:"ca_cert_pem"`
+	ClientCertPEM     types.String             `tfsdk:"client_cert_pem"`
+	ClientKeyPEM      types.String             `tfsdk:"client_key_pem"`
+}
+
+// FunnelProviderAuthModel selects how the provider authenticates at the token endpoint
+type FunnelProviderAuthModel struct {
+	Method           types.String `tfsdk:"method"`
+	PrivateKeyPEM    types.String `tfsdk:"private_key_pem"`
+	PrivateKeyFile   types.String `tfsdk:"private_key_file"`
+	KeyId            types.String `tfsdk:"key_id"`
+	IdTokenFile      types.String `tfsdk:"id_token_file"`
+	IdTokenEnv       types.String `tfsdk:"id_token_env"`
+	IdTokenAudience  types.String `tfsdk:"id_token_audience"`
+	SubjectTokenType types.String `tfsdk:"subject_token_type"`
 }
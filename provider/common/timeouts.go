@@ -0,0 +1,24 @@
+package common
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultTimeout bounds a resource operation, including its retries, when the timeouts block doesn't set it.
+const DefaultTimeout = 10 * time.Minute
+
+// NullTimeouts is the timeouts block of an imported resource, which isn't configured yet.
+func NullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
@@ -0,0 +1,30 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+)
+
+func TestNullTimeouts_MatchesBlock(t *testing.T) {
+	ctx := context.Background()
+
+	value := NullTimeouts()
+	if !value.IsNull() {
+		t.Fatalf("expected null timeouts, got %v", value)
+	}
+
+	blockType := timeouts.BlockAll(ctx).Type()
+	if !value.Type(ctx).Equal(blockType) {
+		t.Fatalf("expected timeouts type %v, got %v", blockType, value.Type(ctx))
+	}
+
+	timeout, diags := value.Read(ctx, DefaultTimeout)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if timeout != DefaultTimeout {
+		t.Fatalf("expected default timeout %s, got %s", DefaultTimeout, timeout)
+	}
+}
@@ -20,7 +20,7 @@ func NewExportFieldDataSource() datasource.DataSource {
 
 // ExportFieldDataSource defines the data source implementation.
 type ExportFieldDataSource struct {
-	config *common.FunnelProviderModel
+	client *funnel.Client
 }
 
 type FunnelExportField struct {
@@ -81,16 +81,16 @@ func (d *ExportFieldDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	config, ok := req.ProviderData.(*common.FunnelProviderModel)
+	client, ok := req.ProviderData.(*funnel.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *FunnelProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.config = config
+	d.client = client
 }
 
 func (d *ExportFieldDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -100,7 +100,7 @@ func (d *ExportFieldDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	field, err := GetExportField(ctx, d.config, config.Workspace.ValueString(), config.Id.ValueString())
+	field, err := GetExportField(ctx, d.client, config.Workspace.ValueString(), config.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Field",
@@ -124,8 +124,8 @@ func (d *ExportFieldDataSource) Read(ctx context.Context, req datasource.ReadReq
 	resp.Diagnostics.Append(resp.State.Set(ctx, &out)...)
 }
 
-func GetExportField(ctx context.Context, config *common.FunnelProviderModel, accountId string, name string) (*FunnelExportField, error) {
-	respObj, err := funnel.GetWorkspaceEntity[FunnelExportFieldJSON](ctx, "fields", config, accountId, name)
+func GetExportField(ctx context.Context, client *funnel.Client, accountId string, name string) (*FunnelExportField, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelExportFieldJSON](ctx, "fields", client, accountId, name)
 	if err != nil {
 		return nil, err
 	}
@@ -137,3 +137,8 @@ func GetExportField(ctx context.Context, config *common.FunnelProviderModel, acc
 
 	return &exportField, nil
 }
+
+// ListExportFields lists the fields of the workspace.
+func ListExportFields(ctx context.Context, client *funnel.Client, accountId string) ([]FunnelExportFieldJSON, error) {
+	return funnel.ListWorkspaceEntities[FunnelExportFieldJSON](ctx, "fields", client, accountId)
+}
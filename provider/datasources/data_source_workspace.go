@@ -20,7 +20,7 @@ func NewWorkspaceDataSource() datasource.DataSource {
 
 // WorkspaceDataSource defines the data source implementation.
 type WorkspaceDataSource struct {
-	config *common.FunnelProviderModel
+	client *funnel.Client
 }
 
 type WorkspaceDataSourceModel struct {
@@ -77,16 +77,16 @@ func (d *WorkspaceDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	config, ok := req.ProviderData.(*common.FunnelProviderModel)
+	client, ok := req.ProviderData.(*funnel.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *FunnelProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.config = config
+	d.client = client
 }
 
 func (d *WorkspaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -96,7 +96,7 @@ func (d *WorkspaceDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	workspace, err := GetWorkspace(ctx, d.config, d.config.SubscriptionId.ValueString(), data.Id.ValueString())
+	workspace, err := GetWorkspace(ctx, d.client, d.client.SubscriptionId, data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Workspace",
@@ -108,8 +108,8 @@ func (d *WorkspaceDataSource) Read(ctx context.Context, req datasource.ReadReque
 	resp.Diagnostics.Append(resp.State.Set(ctx, &workspace)...)
 }
 
-func GetWorkspace(ctx context.Context, config *common.FunnelProviderModel, subscriptionId string, id string) (*WorkspaceDataSourceModel, error) {
-	respObj, err := funnel.GetSubscriptionEntity[FunnelWorkspaceDataJSON](ctx, "workspaces", subscriptionId, id, config)
+func GetWorkspace(ctx context.Context, client *funnel.Client, subscriptionId string, id string) (*WorkspaceDataSourceModel, error) {
+	respObj, err := funnel.GetSubscriptionEntity[FunnelWorkspaceDataJSON](ctx, "workspaces", subscriptionId, id, client)
 	if err != nil {
 		return nil, err
 	}
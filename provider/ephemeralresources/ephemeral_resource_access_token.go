@@ -0,0 +1,104 @@
+package ephemeralresources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &AccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AccessTokenEphemeralResource{}
+
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AccessTokenEphemeralResource{}
+}
+
+// AccessTokenEphemeralResource hands out the access token the provider authenticates with.
+// Ephemeral values are never written to the plan or state.
+type AccessTokenEphemeralResource struct {
+	client *funnel.Client
+}
+
+type AccessTokenEphemeralResourceModel struct {
+	SubscriptionId types.String `tfsdk:"subscription_id"`
+	AccessToken    types.String `tfsdk:"access_token"`
+	TokenType      types.String `tfsdk:"token_type"`
+	ExpiresAt      types.String `tfsdk:"expires_at"`
+}
+
+func (e *AccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (e *AccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Short-lived access token for the Funnel API of the configured subscription. The token is never written to the plan or state.",
+
+		Attributes: map[string]schema.Attribute{
+			"subscription_id": schema.StringAttribute{
+				MarkdownDescription: "Funnel subscription ID the token is for",
+				Computed:            true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Access token for the `Authorization` header of Funnel API requests",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token_type": schema.StringAttribute{
+				MarkdownDescription: "Token type, always `Bearer`",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "Expiry of the token in RFC 3339 format. Null when the token endpoint did not tell when the token expires.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *AccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*funnel.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = client
+}
+
+func (e *AccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	token, err := e.client.TokenSource().Token(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Get Access Token",
+			fmt.Sprintf("Could not get access token: %s", err.Error()),
+		)
+		return
+	}
+
+	data := AccessTokenEphemeralResourceModel{
+		SubscriptionId: types.StringValue(e.client.SubscriptionId),
+		AccessToken:    types.StringValue(token.AccessToken),
+		TokenType:      types.StringValue("Bearer"),
+		ExpiresAt:      types.StringNull(),
+	}
+	if !token.Expiry.IsZero() {
+		data.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,58 @@
+package ephemeralresources
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-funnel/provider/auth"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAccessTokenEphemeralResource_Metadata(t *testing.T) {
+	e := AccessTokenEphemeralResource{}
+
+	resp := &ephemeral.MetadataResponse{}
+	e.Metadata(context.Background(), ephemeral.MetadataRequest{ProviderTypeName: "funnel"}, resp)
+	if resp.TypeName != "funnel_access_token" {
+		t.Fatalf("expected type name funnel_access_token, got %s", resp.TypeName)
+	}
+}
+
+func TestAccessTokenEphemeralResource_Open(t *testing.T) {
+	e := AccessTokenEphemeralResource{
+		client: funnel.NewClient(funnel.ClientConfig{
+			SubscriptionId: "sub-123",
+			TokenSource:    auth.StaticTokenSource("test-token"),
+		}),
+	}
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	e.Schema(context.Background(), ephemeral.SchemaRequest{}, schemaResp)
+
+	resp := &ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+		},
+	}
+	e.Open(context.Background(), ephemeral.OpenRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data AccessTokenEphemeralResourceModel
+	resp.Diagnostics.Append(resp.Result.Get(context.Background(), &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if data.AccessToken.ValueString() != "test-token" || data.SubscriptionId.ValueString() != "sub-123" {
+		t.Errorf("unexpected result: %+v", data)
+	}
+	if !data.ExpiresAt.IsNull() {
+		t.Errorf("expected no expiry, got %s", data.ExpiresAt)
+	}
+}
@@ -0,0 +1,136 @@
+package funnel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"terraform-provider-funnel/provider/auth"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Client sends requests to the Funnel control plane API.
+// It is created once when the provider is configured and shared by all resources and data sources.
+type Client struct {
+	BaseURL        string
+	SubscriptionId string
+
+	httpClient  *http.Client
+	tokenSource auth.TokenSource
+	retry       retryPolicy
+}
+
+type ClientConfig struct {
+	// Environment is one of us, eu, stage or dev, or a custom API URL.
+	Environment string
+	// APIURL replaces the API URL derived from Environment when it is set.
+	APIURL         string
+	SubscriptionId string
+	HTTPClient     *http.Client
+	TokenSource    auth.TokenSource
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// MaxBackoff caps the wait between two attempts. Defaults to DefaultMaxBackoff.
+	MaxBackoff time.Duration
+}
+
+func NewClient(config ClientConfig) *Client {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	maxBackoff := config.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	baseURL := mapEnvironment(config.Environment)
+	if config.APIURL != "" {
+		baseURL = strings.TrimSuffix(config.APIURL, "/")
+	}
+
+	return &Client{
+		BaseURL:        baseURL,
+		SubscriptionId: config.SubscriptionId,
+		httpClient:     httpClient,
+		tokenSource:    config.TokenSource,
+		retry:          retryPolicy{maxRetries: config.MaxRetries, maxBackoff: maxBackoff},
+	}
+}
+
+// TokenSource returns the source of the access tokens the client authenticates with.
+func (c *Client) TokenSource() auth.TokenSource {
+	return c.tokenSource
+}
+
+// do sends a request to the Funnel API and retries it according to the provider retry budget.
+// A 401 response makes it fetch a new access token and send the request once more.
+// The response body is read and closed before returning.
+func (c *Client) do(ctx context.Context, method string, reqURL string, body []byte) (*http.Response, []byte, error) {
+	reauthenticated := false
+
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		token, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get access token: %w", err)
+		}
+
+		ApplyHTTPHeaders(req, token.AuthorizationHeader())
+
+		resp, err := c.httpClient.Do(req)
+		var bodyBytes []byte
+		if err == nil {
+			bodyBytes, _ = io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+
+		// The token may have been revoked or expired early. Authenticate again once before giving up.
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !reauthenticated {
+			tflog.Info(ctx, "Funnel API responded with 401, fetching a new access token")
+			c.tokenSource.Invalidate()
+			reauthenticated = true
+			attempt--
+			continue
+		}
+
+		// Don't retry when Terraform cancelled the operation.
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+
+		if attempt >= c.retry.maxRetries || !shouldRetry(method, resp, err) {
+			return resp, bodyBytes, err
+		}
+
+		wait := c.retry.backoff(attempt, resp)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Retrying %s %s after error: %s", method, reqURL, err), map[string]any{"attempt": attempt + 1, "wait": wait.String()})
+		} else {
+			tflog.Warn(ctx, fmt.Sprintf("Retrying %s %s after status %d", method, reqURL, resp.StatusCode), map[string]any{"attempt": attempt + 1, "wait": wait.String()})
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
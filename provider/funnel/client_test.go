@@ -0,0 +1,26 @@
+package funnel
+
+import "testing"
+
+func TestNewClient_BaseURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		apiURL      string
+		expected    string
+	}{
+		{name: "environment", environment: "eu", expected: "https://controlplane.setup.eu.funnel.io/v1"},
+		{name: "custom url as environment", environment: "http://localhost:8080/v1", expected: "http://localhost:8080/v1"},
+		{name: "api url overrides environment", environment: "us", apiURL: "https://controlplane.example.com/v1", expected: "https://controlplane.example.com/v1"},
+		{name: "trailing slash is trimmed", environment: "us", apiURL: "https://controlplane.example.com/v1/", expected: "https://controlplane.example.com/v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(ClientConfig{Environment: tt.environment, APIURL: tt.apiURL})
+			if client.BaseURL != tt.expected {
+				t.Errorf("expected base URL %q, got %q", tt.expected, client.BaseURL)
+			}
+		})
+	}
+}
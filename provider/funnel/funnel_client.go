@@ -1,13 +1,10 @@
 package funnel
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"terraform-provider-funnel/provider/common"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -46,24 +43,15 @@ func ApplyHTTPHeaders(req *http.Request, token string) {
 	req.Header.Set("User-Agent", "terraform-provider-funnel/"+Version)
 }
 
-func GetSubscriptionEntity[T any](ctx context.Context, entity string, subscriptionId string, id string, config *common.FunnelProviderModel) (T, error) {
+func GetSubscriptionEntity[T any](ctx context.Context, entity string, subscriptionId string, id string, client *Client) (T, error) {
 	var respObj T
 
-	reqURL := fmt.Sprintf("%s/subscriptions/%s/%s/%s", mapEnvironment(config.Environment.ValueString()), subscriptionId, entity, id)
-	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
-	if err != nil {
-		return respObj, err
-	}
-
-	ApplyHTTPHeaders(req, config.Token)
-
-	resp, err := http.DefaultClient.Do(req)
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/%s/%s", client.BaseURL, subscriptionId, entity, id)
+	resp, body, err := client.do(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error reaching GET endpoint: %s", err))
 		return respObj, err
 	}
-	defer resp.Body.Close()
-	body, _ := io.ReadAll(resp.Body)
 
 	if err := HandleHTTPError(resp, body); err != nil {
 		return respObj, err
@@ -76,29 +64,20 @@ func GetSubscriptionEntity[T any](ctx context.Context, entity string, subscripti
 	return respObj, nil
 }
 
-func CreateSubscriptionEntity[T any](ctx context.Context, entity string, subscriptionId string, data T, config *common.FunnelProviderModel) (T, *APIError) {
+func CreateSubscriptionEntity[T any](ctx context.Context, entity string, subscriptionId string, data T, client *Client) (T, *APIError) {
 	var respObj T
 	body, err := json.Marshal(data)
 	if err != nil {
 		return respObj, &APIError{Message: "Failed to marshal request body", Details: err}
 	}
 
-	reqURL := fmt.Sprintf("%s/subscriptions/%s/%s", mapEnvironment(config.Environment.ValueString()), subscriptionId, entity)
-	req, err := http.NewRequest(http.MethodPost, reqURL, bytes.NewBuffer(body))
-	if err != nil {
-		return respObj, &APIError{Message: "Failed to create request", Details: err}
-	}
-
-	ApplyHTTPHeaders(req, config.Token)
-
-	resp, err := http.DefaultClient.Do(req)
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/%s", client.BaseURL, subscriptionId, entity)
+	resp, bodyBytes, err := client.do(ctx, http.MethodPost, reqURL, body)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error creating %s: %s", entity, err))
 		return respObj, &APIError{Message: "Request failed", Details: err}
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, _ := io.ReadAll(resp.Body)
 	if err := HandleHTTPError(resp, bodyBytes); err != nil {
 		if apiErr, ok := err.(APIError); ok {
 			return respObj, &apiErr
@@ -113,29 +92,20 @@ func CreateSubscriptionEntity[T any](ctx context.Context, entity string, subscri
 	return respObj, &APIError{Message: fmt.Sprintf("invalid response from create %s", entity)}
 }
 
-func UpdateSubscriptionEntity[T any](ctx context.Context, entity string, subscriptionId string, id string, data T, config *common.FunnelProviderModel) (T, error) {
+func UpdateSubscriptionEntity[T any](ctx context.Context, entity string, subscriptionId string, id string, data T, client *Client) (T, error) {
 	var respObj T
 	body, err := json.Marshal(data)
 	if err != nil {
 		return respObj, err
 	}
 
-	reqURL := fmt.Sprintf("%s/subscriptions/%s/%s/%s", mapEnvironment(config.Environment.ValueString()), subscriptionId, entity, id)
-	req, err := http.NewRequest(http.MethodPut, reqURL, bytes.NewBuffer(body))
-	if err != nil {
-		return respObj, err
-	}
-
-	ApplyHTTPHeaders(req, config.Token)
-
-	resp, err := http.DefaultClient.Do(req)
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/%s/%s", client.BaseURL, subscriptionId, entity, id)
+	resp, bodyBytes, err := client.do(ctx, http.MethodPut, reqURL, body)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error updating %s: %s", entity, err))
 		return respObj, err
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, _ := io.ReadAll(resp.Body)
 	if err := HandleHTTPError(resp, bodyBytes); err != nil {
 		return respObj, err
 	}
@@ -147,60 +117,62 @@ func UpdateSubscriptionEntity[T any](ctx context.Context, entity string, subscri
 	return respObj, fmt.Errorf("invalid response from update %s", entity)
 }
 
-func GetWorkspaceEntity[T any](ctx context.Context, entity string, config *common.FunnelProviderModel, accountId string, id string) (T, error) {
+func GetWorkspaceEntity[T any](ctx context.Context, entity string, client *Client, accountId string, id string) (T, error) {
 	var respObj T
 
-	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s/%s", mapEnvironment(config.Environment.ValueString()), config.SubscriptionId.ValueString(), accountId, entity, id)
-	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s/%s", client.BaseURL, client.SubscriptionId, accountId, entity, id)
+	resp, body, err := client.do(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("Error reaching GET endpoint: %s", err))
 		return respObj, err
 	}
 
-	ApplyHTTPHeaders(req, config.Token)
+	if err := HandleHTTPError(resp, body); err != nil {
+		return respObj, err
+	}
+
+	if err := json.Unmarshal(body, &respObj); err == nil {
+		return respObj, nil
+	}
+
+	return respObj, nil
+}
 
-	resp, err := http.DefaultClient.Do(req)
+func ListWorkspaceEntities[T any](ctx context.Context, entity string, client *Client, accountId string) ([]T, error) {
+	var respObj []T
+
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s", client.BaseURL, client.SubscriptionId, accountId, entity)
+	resp, body, err := client.do(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error reaching GET endpoint: %s", err))
 		return respObj, err
 	}
-	defer resp.Body.Close()
-	body, _ := io.ReadAll(resp.Body)
 
 	if err := HandleHTTPError(resp, body); err != nil {
 		return respObj, err
 	}
 
-	if err := json.Unmarshal(body, &respObj); err == nil {
-		return respObj, nil
+	if err := json.Unmarshal(body, &respObj); err != nil {
+		return respObj, fmt.Errorf("invalid response from list %s", entity)
 	}
 
 	return respObj, nil
 }
 
-func CreateWorkspaceEntity[TReq any, TResp any](ctx context.Context, entity string, config *common.FunnelProviderModel, accountId string, data TReq) (TResp, *APIError) {
+func CreateWorkspaceEntity[TReq any, TResp any](ctx context.Context, entity string, client *Client, accountId string, data TReq) (TResp, *APIError) {
 	var respObj TResp
 	body, err := json.Marshal(data)
 	if err != nil {
 		return respObj, &APIError{Message: "Failed to marshal request body", Details: err}
 	}
 
-	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s", mapEnvironment(config.Environment.ValueString()), config.SubscriptionId.ValueString(), accountId, entity)
-
-	req, err := http.NewRequest(http.MethodPost, reqURL, bytes.NewBuffer(body))
-	if err != nil {
-		return respObj, &APIError{Message: "Failed to create request", Details: err}
-	}
-
-	ApplyHTTPHeaders(req, config.Token)
-
-	resp, err := http.DefaultClient.Do(req)
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s", client.BaseURL, client.SubscriptionId, accountId, entity)
+	resp, bodyBytes, err := client.do(ctx, http.MethodPost, reqURL, body)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error creating %s: %s", entity, err))
 		return respObj, &APIError{Message: "Request failed", Details: err}
 	}
 
-	defer resp.Body.Close()
-	bodyBytes, _ := io.ReadAll(resp.Body)
 	if err := HandleHTTPError(resp, bodyBytes); err != nil {
 		if apiErr, ok := err.(APIError); ok {
 			return respObj, &apiErr
@@ -217,30 +189,20 @@ func CreateWorkspaceEntity[TReq any, TResp any](ctx context.Context, entity stri
 	return respObj, &APIError{Message: fmt.Sprintf("invalid response from create %s", entity), Details: unmarshalErr}
 }
 
-func UpdateWorkspaceEntity[TReq any, TResp any](ctx context.Context, entity string, config *common.FunnelProviderModel, accountId string, id string, data TReq) (TResp, error) {
+func UpdateWorkspaceEntity[TReq any, TResp any](ctx context.Context, entity string, client *Client, accountId string, id string, data TReq) (TResp, error) {
 	var respObj TResp
 	body, err := json.Marshal(data)
 	if err != nil {
 		return respObj, err
 	}
 
-	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s/%s", mapEnvironment(config.Environment.ValueString()), config.SubscriptionId.ValueString(), accountId, entity, id)
-	req, err := http.NewRequest(http.MethodPut, reqURL, bytes.NewBuffer(body))
-	if err != nil {
-		return respObj, err
-	}
-
-	ApplyHTTPHeaders(req, config.Token)
-
-	resp, err := http.DefaultClient.Do(req)
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s/%s", client.BaseURL, client.SubscriptionId, accountId, entity, id)
+	resp, bodyBytes, err := client.do(ctx, http.MethodPut, reqURL, body)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error updating %s: %s", entity, err))
 		return respObj, err
 	}
 
-	defer resp.Body.Close()
-	bodyBytes, _ := io.ReadAll(resp.Body)
-
 	if err := HandleHTTPError(resp, bodyBytes); err != nil {
 		return respObj, err
 	}
@@ -252,30 +214,20 @@ func UpdateWorkspaceEntity[TReq any, TResp any](ctx context.Context, entity stri
 	return respObj, fmt.Errorf("invalid response from update %s", entity)
 }
 
-func PatchWorkspaceEntity[TReq any, TResp any](ctx context.Context, entity string, config *common.FunnelProviderModel, accountId string, id string, data TReq) (TResp, error) {
+func PatchWorkspaceEntity[TReq any, TResp any](ctx context.Context, entity string, client *Client, accountId string, id string, data TReq) (TResp, error) {
 	var respObj TResp
 	body, err := json.Marshal(data)
 	if err != nil {
 		return respObj, err
 	}
 
-	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s/%s", mapEnvironment(config.Environment.ValueString()), config.SubscriptionId.ValueString(), accountId, entity, id)
-	req, err := http.NewRequest(http.MethodPatch, reqURL, bytes.NewBuffer(body))
-	if err != nil {
-		return respObj, err
-	}
-
-	ApplyHTTPHeaders(req, config.Token)
-
-	resp, err := http.DefaultClient.Do(req)
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s/%s", client.BaseURL, client.SubscriptionId, accountId, entity, id)
+	resp, bodyBytes, err := client.do(ctx, http.MethodPatch, reqURL, body)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error updating %s: %s", entity, err))
 		return respObj, err
 	}
 
-	defer resp.Body.Close()
-	bodyBytes, _ := io.ReadAll(resp.Body)
-
 	if err := HandleHTTPError(resp, bodyBytes); err != nil {
 		return respObj, err
 	}
@@ -287,44 +239,25 @@ func PatchWorkspaceEntity[TReq any, TResp any](ctx context.Context, entity strin
 	return respObj, fmt.Errorf("invalid response from update %s", entity)
 }
 
-func DeleteSubscriptionEntity(ctx context.Context, entity string, subscriptionId string, id string, config *common.FunnelProviderModel) error {
-	reqURL := fmt.Sprintf("%s/subscriptions/%s/%s/%s", mapEnvironment(config.Environment.ValueString()), subscriptionId, entity, id)
-	req, err := http.NewRequest(http.MethodDelete, reqURL, nil)
-	if err != nil {
-		return err
-	}
-
-	ApplyHTTPHeaders(req, config.Token)
-
-	resp, err := http.DefaultClient.Do(req)
+func DeleteSubscriptionEntity(ctx context.Context, entity string, subscriptionId string, id string, client *Client) error {
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/%s/%s", client.BaseURL, subscriptionId, entity, id)
+	resp, bodyBytes, err := client.do(ctx, http.MethodDelete, reqURL, nil)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error deleting %s: %s", entity, err))
 		return err
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, _ := io.ReadAll(resp.Body)
 	return HandleDeleteError(resp, bodyBytes)
 }
 
-func DeleteWorkspaceEntity(ctx context.Context, entity string, config *common.FunnelProviderModel, accountId string, id string) error {
-	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s/%s", mapEnvironment(config.Environment.ValueString()), config.SubscriptionId.ValueString(), accountId, entity, id)
+func DeleteWorkspaceEntity(ctx context.Context, entity string, client *Client, accountId string, id string) error {
+	reqURL := fmt.Sprintf("%s/subscriptions/%s/workspaces/%s/%s/%s", client.BaseURL, client.SubscriptionId, accountId, entity, id)
 
-	req, err := http.NewRequest(http.MethodDelete, reqURL, nil)
-	if err != nil {
-		return err
-	}
-
-	ApplyHTTPHeaders(req, config.Token)
-
-	resp, err := http.DefaultClient.Do(req)
+	resp, bodyBytes, err := client.do(ctx, http.MethodDelete, reqURL, nil)
 	if err != nil {
 		tflog.Error(ctx, fmt.Sprintf("Error deleting %s: %s", entity, err))
 		return err
 	}
 
-	defer resp.Body.Close()
-	bodyBytes, _ := io.ReadAll(resp.Body)
-
 	return HandleDeleteError(resp, bodyBytes)
 }
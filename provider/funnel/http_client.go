@@ -0,0 +1,75 @@
+package funnel
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultRequestTimeout bounds a single HTTP request when the provider does not configure a timeout.
+const DefaultRequestTimeout = 60 * time.Second
+
+// HTTPClientConfig describes how the provider reaches the Funnel API and the token endpoint.
+type HTTPClientConfig struct {
+	// Timeout for a single HTTP request, including reading the response body.
+	Timeout time.Duration
+	// ProxyURL overrides the proxy from the HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// CACertPEM is trusted in addition to the system certificate pool.
+	CACertPEM string
+	// ClientCertPEM and ClientKeyPEM are presented for mutual TLS.
+	ClientCertPEM string
+	ClientKeyPEM  string
+}
+
+// NewHTTPClient builds the HTTP client shared by the Funnel API client and the token sources.
+func NewHTTPClient(config HTTPClientConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", config.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.CACertPEM != "" || config.ClientCertPEM != "" || config.ClientKeyPEM != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if config.CACertPEM != "" {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM([]byte(config.CACertPEM)) {
+				return nil, errors.New("no valid certificates found in the CA certificate PEM")
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		if config.ClientCertPEM != "" || config.ClientKeyPEM != "" {
+			if config.ClientCertPEM == "" || config.ClientKeyPEM == "" {
+				return nil, errors.New("both a client certificate and a client key are required for mutual TLS")
+			}
+			cert, err := tls.X509KeyPair([]byte(config.ClientCertPEM), []byte(config.ClientKeyPEM))
+			if err != nil {
+				return nil, fmt.Errorf("invalid client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
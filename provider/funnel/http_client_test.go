@@ -0,0 +1,90 @@
+package funnel
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"terraform-provider-funnel/provider/auth"
+)
+
+func TestNewHTTPClient_DefaultTimeout(t *testing.T) {
+	client, err := NewHTTPClient(HTTPClientConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.Timeout != DefaultRequestTimeout {
+		t.Errorf("expected timeout %v, got %v", DefaultRequestTimeout, client.Timeout)
+	}
+}
+
+func TestNewHTTPClient_InvalidProxyURL(t *testing.T) {
+	if _, err := NewHTTPClient(HTTPClientConfig{ProxyURL: "not a url"}); err == nil {
+		t.Fatal("expected error for invalid proxy URL, got nil")
+	}
+}
+
+func TestNewHTTPClient_InvalidCACertificate(t *testing.T) {
+	if _, err := NewHTTPClient(HTTPClientConfig{CACertPEM: "not a certificate"}); err == nil {
+		t.Fatal("expected error for invalid CA certificate, got nil")
+	}
+}
+
+func TestNewHTTPClient_ClientCertificateRequiresKey(t *testing.T) {
+	if _, err := NewHTTPClient(HTTPClientConfig{ClientCertPEM: "cert"}); err == nil {
+		t.Fatal("expected error for a client certificate without key, got nil")
+	}
+}
+
+func TestNewHTTPClient_TrustsCustomCACertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"export-1"}`))
+	}))
+	defer server.Close()
+
+	caCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	httpClient, err := NewHTTPClient(HTTPClientConfig{CACertPEM: string(caCertPEM)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewClient(ClientConfig{
+		Environment: server.URL + "/v1",
+		HTTPClient:  httpClient,
+		TokenSource: auth.StaticTokenSource("test-token"),
+	})
+
+	respObj, err := GetWorkspaceEntity[testEntity](context.Background(), "exports", client, "ws-1", "export-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if respObj.Id != "export-1" {
+		t.Errorf("expected id export-1, got %q", respObj.Id)
+	}
+}
+
+func TestClient_AbortsRequestWhenContextIsCancelled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newRetryTestClient(server.URL, 4, auth.StaticTokenSource("test-token"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := GetWorkspaceEntity[testEntity](ctx, "exports", client, "ws-1", "export-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package funnel
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Defaults for the retry budget when the provider does not configure one.
+const (
+	DefaultMaxRetries = 4
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// The first backoff interval. Doubled for every attempt until it reaches the maximum backoff.
+var retryBaseDelay = 500 * time.Millisecond
+
+type retryPolicy struct {
+	maxRetries int
+	maxBackoff time.Duration
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry decides if a failed attempt can be sent again.
+// Idempotent methods are retried on rate limiting, server errors and network errors.
+// A gateway error doesn't tell if Funnel already handled the request, so other methods are only retried when Funnel
+// refused it: on rate limiting, or when it is unavailable and sent a Retry-After.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		return isIdempotent(method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return isIdempotent(method) || resp.Header.Get("Retry-After") != ""
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter reads the Retry-After header as either delay seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// backoff returns the wait before the next attempt, preferring the Retry-After header when the server sent one.
+func (p retryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return min(wait, p.maxBackoff)
+		}
+	}
+
+	wait := p.maxBackoff
+	if attempt < 30 {
+		wait = min(retryBaseDelay<<attempt, p.maxBackoff)
+	}
+
+	// Equal jitter keeps at least half of the exponential wait and spreads the rest.
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+	return half + rand.N(half+1)
+}
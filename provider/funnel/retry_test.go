@@ -0,0 +1,276 @@
+package funnel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terraform-provider-funnel/provider/auth"
+)
+
+type testEntity struct {
+	Id string `json:"id"`
+}
+
+func newRetryTestClient(serverURL string, maxRetries int, tokenSource auth.TokenSource) *Client {
+	return NewClient(ClientConfig{
+		Environment:    serverURL + "/v1",
+		SubscriptionId: "sub-123",
+		TokenSource:    tokenSource,
+		MaxRetries:     maxRetries,
+		MaxBackoff:     10 * time.Millisecond,
+	})
+}
+
+func useFastRetries(t *testing.T) {
+	original := retryBaseDelay
+	retryBaseDelay = time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = original })
+}
+
+func TestGetWorkspaceEntity_RetriesTransientServerErrors(t *testing.T) {
+	useFastRetries(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":"export-1"}`))
+	}))
+	defer server.Close()
+
+	respObj, err := GetWorkspaceEntity[testEntity](context.Background(), "exports", newRetryTestClient(server.URL, 4, auth.StaticTokenSource("test-token")), "ws-1", "export-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if respObj.Id != "export-1" {
+		t.Errorf("expected id export-1, got %q", respObj.Id)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestCreateWorkspaceEntity_RetriesTooManyRequests(t *testing.T) {
+	useFastRetries(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"export-1"}`))
+	}))
+	defer server.Close()
+
+	_, err := CreateWorkspaceEntity[testEntity, testEntity](context.Background(), "exports", newRetryTestClient(server.URL, 4, auth.StaticTokenSource("test-token")), "ws-1", testEntity{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+}
+
+func TestCreateWorkspaceEntity_DoesNotRetryInternalServerError(t *testing.T) {
+	useFastRetries(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := CreateWorkspaceEntity[testEntity, testEntity](context.Background(), "exports", newRetryTestClient(server.URL, 4, auth.StaticTokenSource("test-token")), "ws-1", testEntity{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call for non-idempotent POST, got %d", calls.Load())
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	withRetryAfter := http.Header{"Retry-After": []string{"1"}}
+
+	tests := []struct {
+		name   string
+		method string
+		status int
+		header http.Header
+		want   bool
+	}{
+		{name: "GET 429", method: http.MethodGet, status: http.StatusTooManyRequests, want: true},
+		{name: "GET 502", method: http.MethodGet, status: http.StatusBadGateway, want: true},
+		{name: "GET 503", method: http.MethodGet, status: http.StatusServiceUnavailable, want: true},
+		{name: "GET 404", method: http.MethodGet, status: http.StatusNotFound},
+		{name: "POST 429", method: http.MethodPost, status: http.StatusTooManyRequests, want: true},
+		{name: "POST 502", method: http.MethodPost, status: http.StatusBadGateway},
+		{name: "POST 503", method: http.MethodPost, status: http.StatusServiceUnavailable},
+		{name: "POST 503 with Retry-After", method: http.MethodPost, status: http.StatusServiceUnavailable, header: withRetryAfter, want: true},
+		{name: "PATCH 504", method: http.MethodPatch, status: http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: tt.header}
+			if resp.Header == nil {
+				resp.Header = http.Header{}
+			}
+			if got := shouldRetry(tt.method, resp, nil); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if shouldRetry(http.MethodPost, nil, fmt.Errorf("connection reset")) {
+		t.Error("expected no retry of a POST after a network error")
+	}
+}
+
+func TestCreateWorkspaceEntity_DoesNotRetryBadGateway(t *testing.T) {
+	useFastRetries(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := CreateWorkspaceEntity[testEntity, testEntity](context.Background(), "exports", newRetryTestClient(server.URL, 4, auth.StaticTokenSource("test-token")), "ws-1", testEntity{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call since the export may already be created, got %d", calls.Load())
+	}
+}
+
+func TestDeleteWorkspaceEntity_StopsAfterMaxRetries(t *testing.T) {
+	useFastRetries(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := DeleteWorkspaceEntity(context.Background(), "exports", newRetryTestClient(server.URL, 2, auth.StaticTokenSource("test-token")), "ws-1", "export-1")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls (1 attempt and 2 retries), got %d", calls.Load())
+	}
+}
+
+type countingTokenSource struct {
+	tokens      atomic.Int32
+	invalidated atomic.Int32
+}
+
+func (s *countingTokenSource) Token(ctx context.Context) (*auth.Token, error) {
+	return &auth.Token{AccessToken: fmt.Sprintf("token-%d", s.tokens.Load())}, nil
+}
+
+func (s *countingTokenSource) Invalidate() {
+	s.invalidated.Add(1)
+	s.tokens.Add(1)
+}
+
+func TestGetWorkspaceEntity_ReauthenticatesOnceOnUnauthorized(t *testing.T) {
+	useFastRetries(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id":"export-1"}`))
+	}))
+	defer server.Close()
+
+	tokens := &countingTokenSource{}
+	respObj, err := GetWorkspaceEntity[testEntity](context.Background(), "exports", newRetryTestClient(server.URL, 4, tokens), "ws-1", "export-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if respObj.Id != "export-1" {
+		t.Errorf("expected id export-1, got %q", respObj.Id)
+	}
+	if tokens.invalidated.Load() != 1 {
+		t.Errorf("expected the token to be invalidated once, got %d", tokens.invalidated.Load())
+	}
+}
+
+func TestGetWorkspaceEntity_ReturnsUnauthorizedAfterReauthentication(t *testing.T) {
+	useFastRetries(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := GetWorkspaceEntity[testEntity](context.Background(), "exports", newRetryTestClient(server.URL, 4, auth.StaticTokenSource("test-token")), "ws-1", "export-1")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "empty", value: "", ok: false},
+		{name: "seconds", value: "5", expected: 5 * time.Second, ok: true},
+		{name: "negative seconds", value: "-1", ok: false},
+		{name: "http date", value: "Thu, 01 Jan 2026 12:00:10 GMT", expected: 10 * time.Second, ok: true},
+		{name: "http date in the past", value: "Thu, 01 Jan 2026 11:00:00 GMT", expected: 0, ok: true},
+		{name: "garbage", value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.ok || wait != tt.expected {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, expected %v, %v", tt.value, wait, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_BackoffIsCapped(t *testing.T) {
+	policy := retryPolicy{maxRetries: 10, maxBackoff: 2 * time.Second}
+
+	for attempt := range 10 {
+		if wait := policy.backoff(attempt, nil); wait > policy.maxBackoff {
+			t.Errorf("attempt %d: expected wait at most %v, got %v", attempt, policy.maxBackoff, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if wait := policy.backoff(0, resp); wait != policy.maxBackoff {
+		t.Errorf("expected Retry-After to be capped at %v, got %v", policy.maxBackoff, wait)
+	}
+}
@@ -3,14 +3,24 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 
 	"terraform-provider-funnel/provider/auth"
 	"terraform-provider-funnel/provider/common"
 	"terraform-provider-funnel/provider/datasources"
+	"terraform-provider-funnel/provider/ephemeralresources"
+	"terraform-provider-funnel/provider/funnel"
 	"terraform-provider-funnel/provider/resources"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,7 +30,19 @@ import (
 )
 
 var (
-	_ provider.Provider = &funnelProvider{}
+	_ provider.Provider                       = &funnelProvider{}
+	_ provider.ProviderWithEphemeralResources = &funnelProvider{}
+)
+
+var environments = []string{"us", "eu", "stage", "dev"}
+
+var (
+	environmentSetting    = providerSetting{attribute: "environment", envVar: "FUNNEL_ENVIRONMENT"}
+	subscriptionIdSetting = providerSetting{attribute: "subscription_id", envVar: "FUNNEL_SUBSCRIPTION_ID"}
+	clientIdSetting       = providerSetting{attribute: "client_id", envVar: "FUNNEL_CLIENT_ID"}
+	clientSecretSetting   = providerSetting{attribute: "client_secret", envVar: "FUNNEL_CLIENT_SECRET"}
+	// credentialProcessSetting replaces the client ID and secret with the output of an external command.
+	credentialProcessSetting = providerSetting{attribute: "credential_process", envVar: "FUNNEL_CREDENTIAL_PROCESS"}
 )
 
 func New(version string) func() provider.Provider {
@@ -46,23 +68,82 @@ func (p *funnelProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 		Description: "Manage your Funnel setup.",
 		Attributes: map[string]schema.Attribute{
 			"environment": schema.StringAttribute{
-				MarkdownDescription: "Funnel environment to manage. Default `us`. One of `us`, `eu`, `stage`, or `dev`.",
+				MarkdownDescription: "Funnel environment to manage. Default `us`. One of `us`, `eu`, `stage`, or `dev`. Can also be set with the `FUNNEL_ENVIRONMENT` environment variable or in the credentials profile.",
 				Optional:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("us", "eu", "stage", "dev"),
+					stringvalidator.OneOf(environments...),
 				},
 			},
 			"subscription_id": schema.StringAttribute{
-				MarkdownDescription: "Funnel subscription ID. E.g. `fsXXXXXXXXXXX`.",
-				Required:            true,
+				MarkdownDescription: "Funnel subscription ID. E.g. `fsXXXXXXXXXXX`. Can also be set with the `FUNNEL_SUBSCRIPTION_ID` environment variable or in the credentials profile.",
+				Optional:            true,
 			},
 			"client_id": schema.StringAttribute{
-				MarkdownDescription: "Auth0 Client ID for Funnel.",
-				Required:            true,
+				MarkdownDescription: "Auth0 Client ID for Funnel. Can also be set with the `FUNNEL_CLIENT_ID` environment variable or in the credentials profile.",
+				Optional:            true,
 			},
 			"client_secret": schema.StringAttribute{
-				MarkdownDescription: "Auth0 Client Secret for Funnel.",
-				Required:            true,
+				MarkdownDescription: "Auth0 Client Secret for Funnel. Required by the default `client_credentials` authentication method. Can also be set with the `FUNNEL_CLIENT_SECRET` environment variable or in the credentials profile.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile in the credentials file `~/.funnel/credentials` to read settings from when they are not set in the provider configuration or the environment. Default `default`. Can also be set with the `FUNNEL_PROFILE` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"auth": authSchema(),
+			"credential_process": schema.StringAttribute{
+				MarkdownDescription: "Command that prints Funnel credentials as JSON, either `client_id` and `client_secret` or an `access_token` with an optional RFC 3339 `expires_at`. It runs through the shell and again when the access token is about to expire. Can also be set with the `FUNNEL_CREDENTIAL_PROCESS` environment variable or in the credentials profile.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("auth")),
+				},
+			},
+			"api_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the Funnel API including the version path, e.g. `https://controlplane.example.com/v1`. Overrides the URL derived from `environment`.",
+				Optional:            true,
+			},
+			"token_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the OAuth token endpoint, e.g. `https://login.example.com/oauth/token`. Overrides the token endpoint derived from `environment`.",
+				Optional:            true,
+			},
+			"audience": schema.StringAttribute{
+				MarkdownDescription: "Audience to request access tokens for. Overrides the audience derived from `environment`.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries for rate limited (429) and transient failed requests to the Funnel API. Default `4`. Set to `0` to disable retries.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 20),
+				},
+			},
+			"max_backoff": schema.StringAttribute{
+				MarkdownDescription: "Maximum wait between two retries as a duration, e.g. `30s` or `2m`. Default `30s`. A longer `Retry-After` from the Funnel API is capped at this value.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout for a single HTTP request to the Funnel API or the token endpoint as a duration, e.g. `60s`. Default `60s`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP proxy to reach Funnel through, e.g. `http://proxy.example.com:3128`. Defaults to the `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates to trust in addition to the system certificates, e.g. for a TLS inspecting egress proxy.",
+				Optional:            true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Requires `client_key_pem`.",
+				Optional:            true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate for mutual TLS. Requires `client_cert_pem`.",
+				Optional:            true,
 				Sensitive:           true,
 			},
 		},
@@ -80,23 +161,158 @@ func (p *funnelProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	// Store configuration values
-	if config.Environment.IsNull() || config.Environment.IsUnknown() {
-		config.Environment = types.StringValue("us")
+	// Settings missing in the configuration fall back to environment variables and then to the credentials profile.
+	profile := loadProviderProfile(ctx, config.Profile, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, environmentSource := environmentSetting.resolve(ctx, config.Environment, profile)
+	if environment == "" {
+		environment = "us"
+	} else if !slices.Contains(environments, environment) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Invalid environment",
+			fmt.Sprintf("The environment %q from the %s must be one of: %s.", environment, environmentSource, strings.Join(environments, ", ")),
+		)
+	}
+	config.Environment = types.StringValue(environment)
+	p.environment = environment
+
+	// An auth attribute in the configuration wins over a credential process from the environment or the profile.
+	var missing []providerSetting
+	var credentialSources []string
+	credentialProcess := ""
+	if config.Auth == nil {
+		var source string
+		credentialProcess, source = credentialProcessSetting.resolve(ctx, config.CredentialProcess, profile)
+		if credentialProcess != "" {
+			credentialSources = append(credentialSources, "credential_process from the "+source)
+		}
+	}
+
+	for _, setting := range []struct {
+		providerSetting
+		value *types.String
+	}{
+		{subscriptionIdSetting, &config.SubscriptionId},
+		{clientIdSetting, &config.ClientId},
+		{clientSecretSetting, &config.ClientSecret},
+	} {
+		// Only the client_credentials method authenticates with the client ID and secret,
+		// and a credential process prints them instead.
+		required := true
+		switch setting.providerSetting {
+		case clientIdSetting:
+			required = credentialProcess == ""
+		case clientSecretSetting:
+			required = credentialProcess == "" && authMethod(config.Auth) == authMethodClientCredentials
+		}
+
+		value, source := setting.resolve(ctx, *setting.value, profile)
+		if value == "" && required {
+			missing = append(missing, setting.providerSetting)
+		} else if value != "" {
+			credentialSources = append(credentialSources, fmt.Sprintf("%s from the %s", setting.attribute, source))
+		}
+		*setting.value = types.StringValue(value)
+	}
+	if len(missing) > 0 {
+		resp.Diagnostics.AddError("Missing provider configuration", missingSettingsError(missing, profile))
+	}
+
+	apiURL := parseURLAttribute(config.APIURL, path.Root("api_url"), &resp.Diagnostics)
+	tokenURL := parseURLAttribute(config.TokenURL, path.Root("token_url"), &resp.Diagnostics)
+
+	maxRetries := funnel.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	maxBackoff := parseDurationAttribute(config.MaxBackoff, path.Root("max_backoff"), funnel.DefaultMaxBackoff, &resp.Diagnostics)
+	requestTimeout := parseDurationAttribute(config.RequestTimeout, path.Root("request_timeout"), funnel.DefaultRequestTimeout, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	p.environment = config.Environment.ValueString()
 
-	// Get Auth0 token
-	token, err := auth.GetAccessToken(config.ClientId.ValueString(), config.ClientSecret.ValueString(), config.Environment.ValueString(), ctx)
+	// One HTTP client is shared by the Funnel API client and the Auth0 token requests.
+	httpClient, err := funnel.NewHTTPClient(funnel.HTTPClientConfig{
+		Timeout:       requestTimeout,
+		ProxyURL:      config.ProxyURL.ValueString(),
+		CACertPEM:     config.CACertPEM.ValueString(),
+		ClientCertPEM: config.ClientCertPEM.ValueString(),
+		ClientKeyPEM:  config.ClientKeyPEM.ValueString(),
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get Auth0 token", fmt.Sprintf("Could not get Auth0 token: %v", err))
+		resp.Diagnostics.AddError("Invalid HTTP client configuration", fmt.Sprintf("Could not configure the HTTP client: %v", err))
 		return
 	}
-	config.Token = token
 
-	// Make the configuration available to resources and data sources
-	resp.DataSourceData = &config
-	resp.ResourceData = &config
+	// The token source caches the Auth0 token and refreshes it before it expires.
+	// Fetch the first token right away to report bad credentials during configuration.
+	endpoint := auth.ResolveEndpoint(config.Environment.ValueString(), tokenURL, config.Audience.ValueString())
+	tokenSource, attributePath, err := newTokenSource(httpClient, config.Auth, credentialProcess, config.ClientId.ValueString(), config.ClientSecret.ValueString(), endpoint)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(attributePath, "Invalid authentication configuration", err.Error())
+		return
+	}
+	if _, err := tokenSource.Token(ctx); err != nil {
+		resp.Diagnostics.AddError("Failed to get Auth0 token", fmt.Sprintf("Could not get Auth0 token with %s: %v", strings.Join(credentialSources, ", "), err))
+		return
+	}
+
+	client := funnel.NewClient(funnel.ClientConfig{
+		Environment:    config.Environment.ValueString(),
+		APIURL:         apiURL,
+		SubscriptionId: config.SubscriptionId.ValueString(),
+		HTTPClient:     httpClient,
+		TokenSource:    tokenSource,
+		MaxRetries:     maxRetries,
+		MaxBackoff:     maxBackoff,
+	})
+
+	// Make the client available to resources, data sources and ephemeral resources
+	resp.DataSourceData = client
+	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+// parseDurationAttribute parses a duration attribute like "30s" and falls back to the default when it is not set.
+func parseDurationAttribute(value types.String, attributePath path.Path, fallback time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid duration",
+			fmt.Sprintf("Expected a positive duration like \"30s\" or \"2m\", got: %q", value.ValueString()),
+		)
+		return fallback
+	}
+
+	return d
+}
+
+// parseURLAttribute checks that a URL attribute is an absolute http or https URL and returns it, or "" when it is not set.
+func parseURLAttribute(value types.String, attributePath path.Path, diags *diag.Diagnostics) string {
+	if value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+
+	u, err := url.Parse(value.ValueString())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid URL",
+			fmt.Sprintf("Expected an absolute http or https URL, got: %q", value.ValueString()),
+		)
+		return ""
+	}
+
+	return value.ValueString()
 }
 
 func (p *funnelProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -106,6 +322,12 @@ func (p *funnelProvider) DataSources(ctx context.Context) []func() datasource.Da
 	}
 }
 
+func (p *funnelProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		ephemeralresources.NewAccessTokenEphemeralResource,
+	}
+}
+
 func (p *funnelProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resources.NewWorkspaceResource,
@@ -113,6 +335,13 @@ func (p *funnelProvider) Resources(ctx context.Context) []func() resource.Resour
 		resources.NewBigqueryResource,
 		resources.NewMeasurementResource,
 		resources.NewSnowflakeResource,
+		resources.NewS3Resource,
+		resources.NewAzureBlobResource,
+		resources.NewRedshiftResource,
+		resources.NewDatabricksResource,
+		resources.NewSftpResource,
+		resources.NewGoogleSheetsResource,
+		resources.NewExportResource,
 		resources.NewDataSourceResource,
 		resources.NewCustomDimensionResource,
 		resources.NewCustomMetricResource,
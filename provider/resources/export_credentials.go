@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// writeOnlyCredentials are the write-only credentials of a destination from the configuration, by the name of the
+// stored credential attribute they stand in for. The write-only attribute is named after it with a _wo suffix.
+type writeOnlyCredentials map[string]types.String
+
+// getWriteOnlyCredentials reads the write-only attribute of each named credential of the destination from the configuration,
+// since write-only values are never in the plan or state.
+func getWriteOnlyCredentials(ctx context.Context, config tfsdk.Config, destination path.Path, names ...string) (writeOnlyCredentials, diag.Diagnostics) {
+	credentials := make(writeOnlyCredentials, len(names))
+	var diags diag.Diagnostics
+
+	for _, name := range names {
+		var credential types.String
+		diags.Append(config.GetAttribute(ctx, destination.AtName(name+"_wo"), &credential)...)
+		credentials[name] = credential
+	}
+
+	return credentials, diags
+}
+
+// replace returns the write-only credential in place of the stored one when it's configured. Only the model sent to
+// the API gets it, and the model saved to state keeps the write-only attribute null.
+func (c writeOnlyCredentials) replace(name string, stored types.String) types.String {
+	if credential, ok := c[name]; ok && !credential.IsNull() {
+		return credential
+	}
+	return stored
+}
+
+// keepWriteOnlyVersion keeps the write-only credentials version of the prior state in the version read from Funnel,
+// and reports whether the prior state used write-only credentials, in which case the caller keeps the prior stored
+// credentials too. Funnel doesn't know the version of write-only credentials, and the credentials must stay out of state.
+func keepWriteOnlyVersion(version *types.Int64, prior types.Int64) bool {
+	*version = prior
+	return !prior.IsNull()
+}
+
+// nullUnsetAuthentication nulls the attributes of a way to authenticate when its value read from Funnel is empty.
+// Only one way to authenticate is set on a destination, and the API leaves out the others.
+func nullUnsetAuthentication(value string, attributes ...*types.String) {
+	if value != "" {
+		return
+	}
+	for _, attribute := range attributes {
+		*attribute = types.StringNull()
+	}
+}
@@ -0,0 +1,264 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &exportDestinationResource[any, any, any]{}
+var _ resource.ResourceWithImportState = &exportDestinationResource[any, any, any]{}
+var _ resource.ResourceWithConfigValidators = &exportDestinationResource[any, any, any]{}
+var _ resource.ResourceWithModifyPlan = &exportDestinationResource[any, any, any]{}
+
+// exportDestinationResource is the per-destination resource of a destination of funnel_export without a resource of
+// its own, e.g. funnel_azure_blob_export. Its destination attribute is the destination block of funnel_export.
+type exportDestinationResource[M any, D any, J any] struct {
+	destination exportDestinationAdapter[M, D, J]
+	client      *funnel.Client
+}
+
+func newExportDestinationResource[M any, D any, J any](destination exportDestinationAdapter[M, D, J]) *exportDestinationResource[M, D, J] {
+	return &exportDestinationResource[M, D, J]{destination: destination}
+}
+
+func (r *exportDestinationResource[M, D, J]) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.destination.name + "_export"
+}
+
+func (r *exportDestinationResource[M, D, J]) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = common.GetExportSchema(ctx, r.destination.schema(), r.destination.description)
+}
+
+func (r *exportDestinationResource[M, D, J]) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return append(common.ExportConfigValidators(), r.destination.configValidators(path.Root("destination"))...)
+}
+
+func (r *exportDestinationResource[M, D, J]) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*funnel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *exportDestinationResource[M, D, J]) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(verifyExportFields(ctx, r.client, &resp.Plan)...)
+}
+
+func (r *exportDestinationResource[M, D, J]) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data M
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	shared, destination := r.destination.parts(data)
+
+	createTimeout, diags := shared.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, shared.Workspace.ValueString(), shared.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the export via API
+	shared, destination, err := r.destination.createPlanned(ctx, r.client, req.Config, path.Root("destination"), shared, destination)
+	if err != nil {
+		var apiErr *funnel.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			resp.Diagnostics.AddError(
+				"Export in the same workspace with same destination configuration already exists",
+				fmt.Sprintf("An export with the same configuration already exists: %v", apiErr.Details),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Creating "+r.destination.title+" Export",
+			"Could not create "+r.destination.title+" export: "+err.Error(),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	data = r.destination.newModel(shared, destination)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *exportDestinationResource[M, D, J]) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data M
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	shared, destination := r.destination.parts(data)
+
+	readTimeout, diags := shared.Timeouts.Read(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	export, exportDestination, err := r.destination.readExport(ctx, r.client, shared.Workspace.ValueString(), shared.Id.ValueString(), &destination)
+	if err != nil {
+		// If export not found, remove from state
+		var apiErr funnel.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading "+r.destination.title+" Export",
+			"Could not read "+r.destination.title+" export ID "+shared.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Merge API response with state - preserve ID and workspace, prefer API values for everything else
+	export.Id = shared.Id
+	export.Workspace = shared.Workspace
+	export.Timeouts = shared.Timeouts
+	export.KeepScheduleConfig(shared)
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(shared)...)
+
+	// Save updated data into Terraform state
+	data = r.destination.newModel(export, exportDestination)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *exportDestinationResource[M, D, J]) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data M
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	shared, destination := r.destination.parts(data)
+
+	updateTimeout, diags := shared.Timeouts.Update(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, shared.Workspace.ValueString(), shared.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destination, err := r.destination.updatePlanned(ctx, r.client, req.Config, path.Root("destination"), shared, destination)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating "+r.destination.title+" Export",
+			"Could not update export ID "+shared.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	data = r.destination.newModel(shared, destination)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *exportDestinationResource[M, D, J]) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data M
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	shared, _ := r.destination.parts(data)
+
+	deleteTimeout, diags := shared.Timeouts.Delete(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Delete the export via API
+	err := funnel.DeleteWorkspaceEntity(ctx, "exports", r.client, shared.Workspace.ValueString(), shared.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting "+r.destination.title+" Export",
+			"Could not delete "+r.destination.title+" export ID "+shared.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *exportDestinationResource[M, D, J]) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The import ID format should be "workspace_id/export_id".
+	idParts := strings.Split(req.ID, "/")
+	if len(idParts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected import ID in format 'workspace_id/export_id', got: "+req.ID,
+		)
+		return
+	}
+
+	workspaceID := idParts[0]
+	exportID := idParts[1]
+
+	export, destination, err := r.destination.readExport(ctx, r.client, workspaceID, exportID, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing "+r.destination.title+" Export",
+			"Could not read "+r.destination.title+" export ID "+exportID+" from workspace "+workspaceID+": "+err.Error(),
+		)
+		return
+	}
+
+	export.Id = types.StringValue(exportID)
+	export.Workspace = types.StringValue(workspaceID)
+	export.Timeouts = common.NullTimeouts()
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(common.ExportShared{})...)
+
+	data := r.destination.newModel(export, destination)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newExportDestinationTestPlan returns the plan of the model for the resource, which also serves as its configuration.
+func newExportDestinationTestPlan(t *testing.T, r resource.Resource, model any) tfsdk.Plan {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("could not build plan: %v", diags)
+	}
+	return plan
+}
+
+func TestExportDestinationResource_Create(t *testing.T) {
+	var sent FunnelAzureBlobJSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123"}`))
+	})
+
+	ctx := context.Background()
+	r := newExportDestinationResource(azureBlobExportDestination)
+	r.client = client
+
+	plan := newExportDestinationTestPlan(t, r, newAzureBlobTestModel())
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(ctx), nil)}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+	}
+
+	if sent.Destination.ClientSecret != "wo-secret" {
+		t.Errorf("Expected the write-only client secret of the destination to be sent, got %q", sent.Destination.ClientSecret)
+	}
+
+	var data AzureBlobResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.Id.ValueString() != "export-123" {
+		t.Errorf("Expected the ID of the created export, got %v", data.Id)
+	}
+	if !data.Destination.ClientSecret.IsNull() {
+		t.Errorf("Expected no client secret in state, got %v", data.Destination.ClientSecret)
+	}
+}
+
+func TestExportDestinationResource_Read(t *testing.T) {
+	found := true
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "export-123",
+			"name": "renamed-export",
+			"format": {"type": "csv", "metrics": "export"},
+			"destination": {"type": "azure_blob", "storageAccount": "funnelexports", "container": "marketing", "pathPrefix": "exports", "tenantId": "tenant-id", "clientId": "client-id"}
+		}`))
+	})
+
+	ctx := context.Background()
+	r := newExportDestinationResource(azureBlobExportDestination)
+	r.client = client
+
+	prior := newAzureBlobTestModel()
+	prior.Id = types.StringValue("export-123")
+	prior.Destination.ClientSecretWO = types.StringNull()
+	plan := newExportDestinationTestPlan(t, r, prior)
+	state := tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+	}
+
+	var data AzureBlobResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.Name.ValueString() != "renamed-export" || data.Workspace.ValueString() != "test-workspace" {
+		t.Errorf("Expected the name from the API and the workspace from state, got %v and %v", data.Name, data.Workspace)
+	}
+	if data.Destination.CredentialsWOVersion.ValueInt64() != 1 {
+		t.Errorf("Expected the write-only credentials version to be kept, got %v", data.Destination.CredentialsWOVersion)
+	}
+
+	found = false
+	resp = &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("Expected a deleted export to be removed from state")
+	}
+}
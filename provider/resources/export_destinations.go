@@ -0,0 +1,388 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// exportDestination adapts a destination of the Funnel Exports API to a destination block of funnel_export.
+// The destination is passed around as the object value of its block, and the shared export attributes as common.ExportShared.
+type exportDestination interface {
+	// block is the name of the destination block in funnel_export.
+	block() string
+	// destinationType is the destination type in the Funnel Exports API.
+	destinationType() string
+	// attribute is the destination schema, shared with the destination attribute of the per-destination resource.
+	attribute() schema.SingleNestedAttribute
+	// configValidators are the rules of the destination at the path.
+	configValidators(destination path.Path) []resource.ConfigValidator
+	// legacyResource is the per-destination resource that funnel_export can move state from.
+	legacyResource() resource.Resource
+
+	// read gets the export from Funnel. The prior destination is null on import.
+	read(ctx context.Context, client *funnel.Client, workspace string, id string, prior types.Object) (common.ExportShared, types.Object, error)
+	// create creates the export and returns it as it's saved to state.
+	create(ctx context.Context, client *funnel.Client, config tfsdk.Config, shared common.ExportShared, destination types.Object) (common.ExportShared, types.Object, error)
+	// update updates the export and returns it as it's saved to state.
+	update(ctx context.Context, client *funnel.Client, config tfsdk.Config, shared common.ExportShared, destination types.Object) (common.ExportShared, types.Object, error)
+}
+
+// exportDestinations is the registry of destinations of funnel_export. A new destination adds its adapter here, and the
+// exportDestinationResource of the adapter to the provider as its per-destination resource.
+var exportDestinations = []exportDestination{
+	exportDestinationAdapter[FunnelGCSResource, FunnelGCSDestination, FunnelGCSJSON]{
+		name:        "gcs",
+		apiType:     "gcs",
+		schema:      gcsDestinationAttribute,
+		newResource: NewGCSResource,
+		newModel: func(shared common.ExportShared, destination FunnelGCSDestination) FunnelGCSResource {
+			return FunnelGCSResource{ExportShared: shared, Destination: destination}
+		},
+		parts: func(model FunnelGCSResource) (common.ExportShared, FunnelGCSDestination) {
+			return model.ExportShared, model.Destination
+		},
+		exportId:     func(respObj FunnelGCSJSON) string { return respObj.Id },
+		get:          getExport,
+		createExport: createExport,
+		updateExport: updateExport,
+		fromAPI:      setGCSDestinationFromAPI,
+	},
+	exportDestinationAdapter[BigqueryResourceModel, ExportBigqueryDestination, FunnelBigqueryJSON]{
+		name:        "bigquery",
+		apiType:     "bigquery",
+		schema:      bigqueryDestinationAttribute,
+		newResource: NewBigqueryResource,
+		newModel: func(shared common.ExportShared, destination ExportBigqueryDestination) BigqueryResourceModel {
+			return BigqueryResourceModel{ExportShared: shared, Destination: destination}
+		},
+		parts: func(model BigqueryResourceModel) (common.ExportShared, ExportBigqueryDestination) {
+			return model.ExportShared, model.Destination
+		},
+		exportId:     func(respObj FunnelBigqueryJSON) string { return respObj.Id },
+		get:          getBigqueryExport,
+		createExport: createBigqueryExport,
+		updateExport: updateBigqueryExport,
+		fromAPI: func(destination *ExportBigqueryDestination, respObj FunnelBigqueryJSON) {
+			setBigqueryDestinationFromAPI(destination, respObj.Destination)
+		},
+	},
+	exportDestinationAdapter[SnowflakeResourceModel, ExportSnowflakeDestination, FunnelSnowflakeJSON]{
+		name:        "snowflake",
+		apiType:     "snowflake",
+		schema:      snowflakeDestinationAttribute,
+		validators:  snowflakeDestinationValidators,
+		newResource: NewSnowflakeResource,
+		newModel: func(shared common.ExportShared, destination ExportSnowflakeDestination) SnowflakeResourceModel {
+			return SnowflakeResourceModel{ExportShared: shared, Destination: destination}
+		},
+		parts: func(model SnowflakeResourceModel) (common.ExportShared, ExportSnowflakeDestination) {
+			return model.ExportShared, model.Destination
+		},
+		exportId:        func(respObj FunnelSnowflakeJSON) string { return respObj.Id },
+		get:             getSnowflakeExport,
+		createExport:    createSnowflakeExport,
+		updateExport:    updateSnowflakeExport,
+		credentialNames: snowflakeWriteOnlyCredentialNames,
+		withCredentials: withSnowflakeWriteOnlyCredentials,
+		keepState:       keepSnowflakeWriteOnlyState,
+	},
+	exportDestinationAdapter[MeasurementResourceModel, ExportMeasurementDestination, FunnelMeasurementJSON]{
+		name:        "measurement",
+		apiType:     "iceberg",
+		schema:      measurementDestinationAttribute,
+		validators:  measurementDestinationValidators,
+		newResource: NewMeasurementResource,
+		newModel: func(shared common.ExportShared, destination ExportMeasurementDestination) MeasurementResourceModel {
+			return MeasurementResourceModel{ExportShared: shared, Destination: destination}
+		},
+		parts: func(model MeasurementResourceModel) (common.ExportShared, ExportMeasurementDestination) {
+			return model.ExportShared, model.Destination
+		},
+		exportId:     func(respObj FunnelMeasurementJSON) string { return respObj.Id },
+		get:          getMeasurementExport,
+		createExport: createMeasurementExport,
+		updateExport: updateMeasurementExport,
+	},
+	s3ExportDestination,
+	azureBlobExportDestination,
+	redshiftExportDestination,
+	databricksExportDestination,
+	sftpExportDestination,
+	googleSheetsExportDestination,
+}
+
+// exportDestinationForType returns the destination with the Funnel Exports API destination type.
+func exportDestinationForType(destinationType string) (exportDestination, bool) {
+	for _, d := range exportDestinations {
+		if d.destinationType() == destinationType {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// exportDestinationForBlock returns the destination with the block name.
+func exportDestinationForBlock(block string) (exportDestination, bool) {
+	for _, d := range exportDestinations {
+		if d.block() == block {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// destinationAttributeTypes are the attribute types of the destination block.
+func destinationAttributeTypes(d exportDestination) map[string]attr.Type {
+	return d.attribute().GetType().(types.ObjectType).AttrTypes
+}
+
+// destinationObject converts the destination model of an adapter to the value of its block.
+func destinationObject(ctx context.Context, d exportDestination, destination any) (types.Object, error) {
+	object, diags := types.ObjectValueFrom(ctx, destinationAttributeTypes(d), destination)
+	return object, diagnosticsError(diags)
+}
+
+// destinationModel converts the value of a destination block to the destination model of its adapter.
+func destinationModel(ctx context.Context, object types.Object, destination any) error {
+	return diagnosticsError(object.As(ctx, destination, basetypes.ObjectAsOptions{}))
+}
+
+// diagnosticsError returns the first error diagnostic as an error.
+func diagnosticsError(diags diag.Diagnostics) error {
+	for _, d := range diags.Errors() {
+		return fmt.Errorf("%s: %s", d.Summary(), d.Detail())
+	}
+	return nil
+}
+
+// exportDestinationAdapter is the adapter of a destination from the per-destination resource, with its resource
+// model M, destination model D and Funnel Exports API export J.
+type exportDestinationAdapter[M any, D any, J any] struct {
+	name       string
+	apiType    string
+	schema     func() schema.SingleNestedAttribute
+	validators func(destination path.Path) []resource.ConfigValidator
+
+	// newResource is the resource of a destination with a resource of its own. The others get an
+	// exportDestinationResource described by description, with title naming the destination in its errors.
+	newResource func() resource.Resource
+	title       string
+	description string
+
+	// newModel and parts convert between the resource model and its shared attributes and destination.
+	newModel func(shared common.ExportShared, destination D) M
+	parts    func(model M) (common.ExportShared, D)
+	// exportId is the id of the export created by Funnel.
+	exportId func(respObj J) string
+
+	get func(ctx context.Context, client *funnel.Client, accountId string, id string) (*M, error)
+
+	// prepare sets the defaults and conversions of the export sent to the API, unless the destination has its own
+	// createExport and updateExport.
+	prepare      func(ctx context.Context, data *J, model M) error
+	createExport func(ctx context.Context, client *funnel.Client, model M) (J, *funnel.APIError)
+	updateExport func(ctx context.Context, client *funnel.Client, model M) (J, error)
+
+	// credentialNames, withCredentials and keepState handle the write-only credentials of the destination, if any.
+	credentialNames []string
+	withCredentials func(model M, credentials writeOnlyCredentials) M
+	keepState       func(destination *D, prior D)
+
+	// fromAPI sets the attributes defaulted by Funnel in the planned destination, if any.
+	fromAPI func(destination *D, respObj J)
+}
+
+func (a exportDestinationAdapter[M, D, J]) block() string {
+	return a.name
+}
+
+func (a exportDestinationAdapter[M, D, J]) destinationType() string {
+	return a.apiType
+}
+
+func (a exportDestinationAdapter[M, D, J]) attribute() schema.SingleNestedAttribute {
+	return a.schema()
+}
+
+func (a exportDestinationAdapter[M, D, J]) legacyResource() resource.Resource {
+	if a.newResource != nil {
+		return a.newResource()
+	}
+	return newExportDestinationResource(a)
+}
+
+func (a exportDestinationAdapter[M, D, J]) configValidators(destination path.Path) []resource.ConfigValidator {
+	if a.validators == nil {
+		return nil
+	}
+	return a.validators(destination)
+}
+
+func (a exportDestinationAdapter[M, D, J]) read(ctx context.Context, client *funnel.Client, workspace string, id string, prior types.Object) (common.ExportShared, types.Object, error) {
+	var priorDestination *D
+	if !prior.IsNull() {
+		priorDestination = new(D)
+		if err := destinationModel(ctx, prior, priorDestination); err != nil {
+			return common.ExportShared{}, types.Object{}, err
+		}
+	}
+
+	shared, destination, err := a.readExport(ctx, client, workspace, id, priorDestination)
+	if err != nil {
+		return common.ExportShared{}, types.Object{}, err
+	}
+
+	object, err := destinationObject(ctx, a, destination)
+	return shared, object, err
+}
+
+func (a exportDestinationAdapter[M, D, J]) create(ctx context.Context, client *funnel.Client, config tfsdk.Config, shared common.ExportShared, destination types.Object) (common.ExportShared, types.Object, error) {
+	var planned D
+	if err := destinationModel(ctx, destination, &planned); err != nil {
+		return shared, destination, err
+	}
+
+	shared, planned, err := a.createPlanned(ctx, client, config, path.Root(a.name), shared, planned)
+	if err != nil {
+		return shared, destination, err
+	}
+
+	object, err := destinationObject(ctx, a, planned)
+	return shared, object, err
+}
+
+func (a exportDestinationAdapter[M, D, J]) update(ctx context.Context, client *funnel.Client, config tfsdk.Config, shared common.ExportShared, destination types.Object) (common.ExportShared, types.Object, error) {
+	var planned D
+	if err := destinationModel(ctx, destination, &planned); err != nil {
+		return shared, destination, err
+	}
+
+	planned, err := a.updatePlanned(ctx, client, config, path.Root(a.name), shared, planned)
+	if err != nil {
+		return shared, destination, err
+	}
+
+	object, err := destinationObject(ctx, a, planned)
+	return shared, object, err
+}
+
+// readExport gets the export from Funnel. The prior destination is nil on import.
+func (a exportDestinationAdapter[M, D, J]) readExport(ctx context.Context, client *funnel.Client, workspace string, id string, prior *D) (common.ExportShared, D, error) {
+	var destination D
+	export, err := a.get(ctx, client, workspace, id)
+	if err != nil {
+		return common.ExportShared{}, destination, err
+	}
+
+	shared, destination := a.parts(*export)
+	if a.keepState != nil && prior != nil {
+		a.keepState(&destination, *prior)
+	}
+	return shared, destination, nil
+}
+
+// createPlanned creates the planned export, with the write-only credentials of the destination at the path of the
+// configuration, and returns it as it's saved to state.
+func (a exportDestinationAdapter[M, D, J]) createPlanned(ctx context.Context, client *funnel.Client, config tfsdk.Config, destinationPath path.Path, shared common.ExportShared, planned D) (common.ExportShared, D, error) {
+	model, err := a.withConfigCredentials(ctx, config, destinationPath, a.newModel(shared, planned))
+	if err != nil {
+		return shared, planned, err
+	}
+
+	respObj, apiErr := a.createModel(ctx, client, model)
+	if apiErr != nil {
+		return shared, planned, apiErr
+	}
+
+	shared.Id = types.StringValue(a.exportId(respObj))
+	if a.fromAPI != nil {
+		a.fromAPI(&planned, respObj)
+	}
+	return shared, planned, nil
+}
+
+// updatePlanned updates the export to the plan, with the write-only credentials of the destination at the path of the
+// configuration, and returns the destination as it's saved to state.
+func (a exportDestinationAdapter[M, D, J]) updatePlanned(ctx context.Context, client *funnel.Client, config tfsdk.Config, destinationPath path.Path, shared common.ExportShared, planned D) (D, error) {
+	model, err := a.withConfigCredentials(ctx, config, destinationPath, a.newModel(shared, planned))
+	if err != nil {
+		return planned, err
+	}
+
+	respObj, err := a.updateModel(ctx, client, model)
+	if err != nil {
+		return planned, err
+	}
+
+	if a.fromAPI != nil {
+		a.fromAPI(&planned, respObj)
+	}
+	return planned, nil
+}
+
+// withConfigCredentials returns the model sent to the API, which unlike the planned destination has the write-only
+// credentials of the destination at the path of the configuration.
+func (a exportDestinationAdapter[M, D, J]) withConfigCredentials(ctx context.Context, config tfsdk.Config, destinationPath path.Path, model M) (M, error) {
+	if len(a.credentialNames) == 0 {
+		return model, nil
+	}
+
+	credentials, diags := getWriteOnlyCredentials(ctx, config, destinationPath, a.credentialNames...)
+	if err := diagnosticsError(diags); err != nil {
+		return model, err
+	}
+
+	return a.withCredentials(model, credentials), nil
+}
+
+// createModel creates the export of the model in Funnel.
+func (a exportDestinationAdapter[M, D, J]) createModel(ctx context.Context, client *funnel.Client, model M) (J, *funnel.APIError) {
+	if a.createExport != nil {
+		return a.createExport(ctx, client, model)
+	}
+
+	data, err := a.exportJSON(ctx, model)
+	if err != nil {
+		return data, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
+	}
+
+	shared, _ := a.parts(model)
+	return funnel.CreateWorkspaceEntity[J, J](ctx, "exports", client, shared.Workspace.ValueString(), data)
+}
+
+// updateModel updates the export in Funnel to the model.
+func (a exportDestinationAdapter[M, D, J]) updateModel(ctx context.Context, client *funnel.Client, model M) (J, error) {
+	if a.updateExport != nil {
+		return a.updateExport(ctx, client, model)
+	}
+
+	data, err := a.exportJSON(ctx, model)
+	if err != nil {
+		return data, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
+	}
+
+	shared, _ := a.parts(model)
+	return funnel.UpdateWorkspaceEntity[J, J](ctx, "exports", client, shared.Workspace.ValueString(), shared.Id.ValueString(), data)
+}
+
+// exportJSON converts the model to the export sent to the API.
+func (a exportDestinationAdapter[M, D, J]) exportJSON(ctx context.Context, model M) (J, error) {
+	data, err := common.ConvertTFToJSON[M, J](model)
+	if err != nil {
+		return data, err
+	}
+
+	err = a.prepare(ctx, &data, model)
+	return data, err
+}
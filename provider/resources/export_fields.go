@@ -0,0 +1,185 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/datasources"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Maximum number of close matches suggested for an unknown field ID.
+const maxFieldSuggestions = 3
+
+// verifyExportFields resolves the field IDs of the planned export against the workspace.
+// Unknown IDs are reported as errors on the field with close matches, and an empty field type
+// is planned as the type of the workspace field.
+func verifyExportFields(ctx context.Context, client *funnel.Client, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Nothing to verify when the export is destroyed or the provider isn't configured yet
+	if client == nil || plan.Raw.IsNull() {
+		return diags
+	}
+
+	var workspace types.String
+	var fieldList types.List
+	diags.Append(plan.GetAttribute(ctx, path.Root("workspace"), &workspace)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("fields"), &fieldList)...)
+	if diags.HasError() || workspace.IsUnknown() || workspace.IsNull() || fieldList.IsUnknown() || fieldList.IsNull() {
+		return diags
+	}
+
+	var fields []common.ExportField
+	diags.Append(fieldList.ElementsAs(ctx, &fields, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// One listing of the workspace fields verifies all of them, since plans of many exports would otherwise be rate limited
+	var workspaceFields map[string]datasources.FunnelExportFieldJSON
+	var workspaceFieldIds []string
+	for i, field := range fields {
+		if field.Id.IsUnknown() || field.Id.IsNull() {
+			continue
+		}
+		fieldPath := path.Root("fields").AtListIndex(i)
+
+		if workspaceFields == nil {
+			listed, err := datasources.ListExportFields(ctx, client, workspace.ValueString())
+			if err != nil {
+				diags.AddAttributeWarning(
+					path.Root("fields"),
+					"Unable to Verify Export Fields",
+					fmt.Sprintf("Could not list the fields of workspace %s: %s", workspace.ValueString(), err.Error()),
+				)
+				return diags
+			}
+
+			workspaceFields = make(map[string]datasources.FunnelExportFieldJSON, len(listed))
+			for _, workspaceField := range listed {
+				workspaceFields[workspaceField.Id] = workspaceField
+				workspaceFieldIds = append(workspaceFieldIds, workspaceField.Id)
+			}
+		}
+
+		workspaceField, ok := workspaceFields[field.Id.ValueString()]
+		if !ok {
+			detail := fmt.Sprintf("Field %s does not exist in workspace %s.", field.Id.ValueString(), workspace.ValueString())
+			if suggestions := suggestFieldIds(field.Id.ValueString(), workspaceFieldIds); len(suggestions) > 0 {
+				detail += " Did you mean " + strings.Join(suggestions, ", ") + "?"
+			}
+			diags.AddAttributeError(fieldPath.AtName("id"), "Unknown Export Field", detail)
+			continue
+		}
+
+		if field.Type.IsUnknown() || field.Type.IsNull() {
+			diags.Append(plan.SetAttribute(ctx, fieldPath.AtName("type"), types.StringValue(workspaceField.Type))...)
+		}
+	}
+
+	return diags
+}
+
+// resolveExportFieldTypes sets the types still unknown at apply, when the workspace wasn't known during plan.
+// Like verifyExportFields, it lists the workspace fields once rather than looking up each field.
+func resolveExportFieldTypes(ctx context.Context, client *funnel.Client, workspace string, fields []common.ExportField) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var workspaceFields map[string]datasources.FunnelExportFieldJSON
+	for i, field := range fields {
+		if !field.Type.IsUnknown() {
+			continue
+		}
+		typePath := path.Root("fields").AtListIndex(i).AtName("type")
+
+		if workspaceFields == nil {
+			listed, err := datasources.ListExportFields(ctx, client, workspace)
+			if err != nil {
+				diags.AddAttributeError(
+					typePath,
+					"Unable to Resolve Export Field Type",
+					fmt.Sprintf("Could not list the fields of workspace %s: %s", workspace, err.Error()),
+				)
+				return diags
+			}
+
+			workspaceFields = make(map[string]datasources.FunnelExportFieldJSON, len(listed))
+			for _, workspaceField := range listed {
+				workspaceFields[workspaceField.Id] = workspaceField
+			}
+		}
+
+		workspaceField, ok := workspaceFields[field.Id.ValueString()]
+		if !ok {
+			diags.AddAttributeError(
+				typePath,
+				"Unable to Resolve Export Field Type",
+				fmt.Sprintf("Field %s does not exist in workspace %s.", field.Id.ValueString(), workspace),
+			)
+			continue
+		}
+		fields[i].Type = types.StringValue(workspaceField.Type)
+	}
+
+	return diags
+}
+
+// suggestFieldIds returns the candidates closest to the ID, at most a third of the ID length in edits away.
+func suggestFieldIds(id string, candidates []string) []string {
+	maxDistance := max(len(id)/3, 1)
+
+	type match struct {
+		id       string
+		distance int
+	}
+	var matches []match
+	for _, candidate := range candidates {
+		if distance := editDistance(strings.ToLower(id), strings.ToLower(candidate)); distance <= maxDistance {
+			matches = append(matches, match{id: candidate, distance: distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	suggestions := make([]string, 0, maxFieldSuggestions)
+	for _, m := range matches {
+		if len(suggestions) == maxFieldSuggestions {
+			break
+		}
+		suggestions = append(suggestions, m.id)
+	}
+	return suggestions
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,186 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"terraform-provider-funnel/provider/auth"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSuggestFieldIds(t *testing.T) {
+	candidates := []string{"Impressions", "Clicks", "Cost", "Conversions", "Sessions"}
+
+	tests := []struct {
+		id       string
+		expected []string
+	}{
+		{id: "Impresions", expected: []string{"Impressions"}},
+		{id: "clicks", expected: []string{"Clicks"}},
+		{id: "Costs", expected: []string{"Cost"}},
+		{id: "Revenue", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got := suggestFieldIds(tt.id, candidates)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "", b: "abc", expected: 3},
+		{a: "abc", b: "abc", expected: 0},
+		{a: "kitten", b: "sitting", expected: 3},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.expected {
+			t.Fatalf("expected distance %d between %q and %q, got %d", tt.expected, tt.a, tt.b, got)
+		}
+	}
+}
+
+func TestResolveExportFieldTypes(t *testing.T) {
+	var requests atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/workspaces/ws-123/fields") {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"id":"Clicks","name":"Clicks","type":"metric"},{"id":"Date","name":"Date","type":"dimension"}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockServer.Close()
+
+	client := funnel.NewClient(funnel.ClientConfig{
+		Environment: mockServer.URL + "/v1",
+		TokenSource: auth.StaticTokenSource("test-token"),
+	})
+
+	fields := []common.ExportField{
+		{Id: types.StringValue("Clicks"), Type: types.StringUnknown()},
+		{Id: types.StringValue("Cost"), Type: types.StringValue("metric")},
+		{Id: types.StringValue("Date"), Type: types.StringUnknown()},
+	}
+
+	diags := resolveExportFieldTypes(context.Background(), client, "ws-123", fields)
+	if diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+	if fields[0].Type.ValueString() != "metric" {
+		t.Fatalf("expected resolved type metric, got %v", fields[0].Type)
+	}
+	if fields[2].Type.ValueString() != "dimension" {
+		t.Fatalf("expected resolved type dimension, got %v", fields[2].Type)
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("expected 1 request, got %d", requests.Load())
+	}
+
+	fields = []common.ExportField{{Id: types.StringValue("Unknown"), Type: types.StringUnknown()}}
+	diags = resolveExportFieldTypes(context.Background(), client, "ws-123", fields)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a field missing from the workspace")
+	}
+}
+
+func TestVerifyExportFields(t *testing.T) {
+	var requests atomic.Int32
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/workspaces/test-workspace/fields") {
+			t.Errorf("expected one listing of the workspace fields, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"Clicks","name":"Clicks","type":"metric"},{"id":"Impressions","name":"Impressions","type":"metric"},{"id":"Date","name":"Date","type":"dimension"}]`))
+	})
+
+	ctx := context.Background()
+	r := newExportDestinationResource(s3ExportDestination)
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	field := func(id string, fieldType types.String) common.ExportField {
+		return common.ExportField{Id: types.StringValue(id), Type: fieldType, ExportType: types.StringNull(), ExportName: types.StringNull()}
+	}
+	data := newS3TestModel()
+	data.Fields = []common.ExportField{
+		field("Clicks", types.StringUnknown()),
+		field("Date", types.StringValue("dimension")),
+		field("Impresions", types.StringUnknown()),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := plan.Set(ctx, data); diags.HasError() {
+		t.Fatalf("could not build plan: %v", diags)
+	}
+
+	diags := verifyExportFields(ctx, client, &plan)
+	if requests.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", requests.Load())
+	}
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", diags)
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("fields").AtListIndex(2).AtName("id")) {
+		t.Errorf("expected the error on the third field, got %v", diags.Errors()[0])
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "Did you mean Impressions?") {
+		t.Errorf("expected a suggestion, got %q", detail)
+	}
+
+	var planned []common.ExportField
+	plan.GetAttribute(ctx, path.Root("fields"), &planned)
+	if planned[0].Type.ValueString() != "metric" {
+		t.Errorf("expected the type of the workspace field, got %v", planned[0].Type)
+	}
+	if planned[1].Type.ValueString() != "dimension" {
+		t.Errorf("expected the configured type to be kept, got %v", planned[1].Type)
+	}
+}
+
+func TestVerifyExportFields_ListFails(t *testing.T) {
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	ctx := context.Background()
+	r := newExportDestinationResource(s3ExportDestination)
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := newS3TestModel()
+	data.Fields = []common.ExportField{{Id: types.StringValue("Clicks"), Type: types.StringUnknown(), ExportType: types.StringNull(), ExportName: types.StringNull()}}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := plan.Set(ctx, data); diags.HasError() {
+		t.Fatalf("could not build plan: %v", diags)
+	}
+
+	diags := verifyExportFields(ctx, client, &plan)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected only a warning, got %v", diags)
+	}
+}
@@ -0,0 +1,260 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// azureBlobExportDestination is the Azure Blob Storage destination of funnel_azure_blob_export and funnel_export.
+var azureBlobExportDestination = exportDestinationAdapter[AzureBlobResourceModel, ExportAzureBlobDestination, FunnelAzureBlobJSON]{
+	name:        "azure_blob",
+	apiType:     "azure_blob",
+	schema:      azureBlobDestinationAttribute,
+	validators:  azureBlobDestinationValidators,
+	title:       "Azure Blob Storage",
+	description: "Azure Blob Storage export. Authenticate with either a SAS token or a service principal.",
+	newModel: func(shared common.ExportShared, destination ExportAzureBlobDestination) AzureBlobResourceModel {
+		return AzureBlobResourceModel{ExportShared: shared, Destination: destination}
+	},
+	parts: func(model AzureBlobResourceModel) (common.ExportShared, ExportAzureBlobDestination) {
+		return model.ExportShared, model.Destination
+	},
+	exportId:        func(respObj FunnelAzureBlobJSON) string { return respObj.Id },
+	get:             getAzureBlobExport,
+	prepare:         prepareAzureBlobExportData,
+	credentialNames: azureBlobWriteOnlyCredentialNames,
+	withCredentials: withAzureBlobWriteOnlyCredentials,
+	keepState:       keepAzureBlobWriteOnlyState,
+}
+
+func NewAzureBlobResource() resource.Resource {
+	return newExportDestinationResource(azureBlobExportDestination)
+}
+
+type ExportAzureBlobDestination struct {
+	StorageAccount       types.String `tfsdk:"storage_account"`
+	Container            types.String `tfsdk:"container"`
+	PathPrefix           types.String `tfsdk:"path_prefix"`
+	OutputIdTemplate     types.String `tfsdk:"output_id_template"`
+	SASToken             types.String `tfsdk:"sas_token"`
+	TenantId             types.String `tfsdk:"tenant_id"`
+	ClientId             types.String `tfsdk:"client_id"`
+	ClientSecret         types.String `tfsdk:"client_secret"`
+	SASTokenWO           types.String `tfsdk:"sas_token_wo"`
+	ClientSecretWO       types.String `tfsdk:"client_secret_wo"`
+	CredentialsWOVersion types.Int64  `tfsdk:"credentials_wo_version"`
+}
+
+type AzureBlobResourceModel struct {
+	Destination ExportAzureBlobDestination `tfsdk:"destination"`
+	common.ExportShared
+}
+
+type FunnelAzureBlobDestinationJSON struct {
+	Type             string `json:"type"`
+	StorageAccount   string `json:"storageAccount"`
+	Container        string `json:"container"`
+	PathPrefix       string `json:"pathPrefix"`
+	OutputIdTemplate string `json:"outputIdTemplate"`
+	SASToken         string `json:"sasToken,omitempty"`
+	TenantId         string `json:"tenantId,omitempty"`
+	ClientId         string `json:"clientId,omitempty"`
+	ClientSecret     string `json:"clientSecret,omitempty"`
+}
+
+type FunnelAzureBlobJSON struct {
+	Destination FunnelAzureBlobDestinationJSON `json:"destination"`
+	common.ExportSharedJSON
+}
+
+// azureBlobDestinationAttribute is the Azure Blob Storage destination, shared with the azure_blob block of funnel_export.
+func azureBlobDestinationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Azure Blob Storage destination container",
+		Required:    true,
+		Attributes: map[string]schema.Attribute{
+			"storage_account": schema.StringAttribute{
+				Description: "Name of the Azure storage account",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-z0-9]{3,24}$`),
+						"must be 3 to 24 lowercase letters and numbers",
+					),
+				},
+			},
+			"container": schema.StringAttribute{
+				Description: "Blob container to export data to",
+				Required:    true,
+			},
+			"path_prefix": schema.StringAttribute{
+				Description: "Prefix of the blob names in the container",
+				Required:    true,
+			},
+			"output_id_template": schema.StringAttribute{
+				Description: "Output ID template for the export",
+				Required:    true,
+			},
+			"sas_token": schema.StringAttribute{
+				Description: "Shared access signature (SAS) token with write access to the container",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description: "Microsoft Entra tenant ID of the service principal",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRelative().AtParent().AtName("sas_token"),
+						path.MatchRelative().AtParent().AtName("sas_token_wo"),
+					),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				Description: "Application (client) ID of the service principal",
+				Optional:    true,
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "Client secret of the service principal",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("tenant_id")),
+				},
+			},
+			"sas_token_wo": schema.StringAttribute{
+				Description: "Write-only SAS token that is never stored in the plan or state. Requires Terraform 1.11 or later and credentials_wo_version.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("sas_token")),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("credentials_wo_version")),
+				},
+			},
+			"client_secret_wo": schema.StringAttribute{
+				Description: "Write-only client secret of the service principal that is never stored in the plan or state. Requires Terraform 1.11 or later and credentials_wo_version.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("client_secret")),
+					stringvalidator.AlsoRequires(
+						path.MatchRelative().AtParent().AtName("tenant_id"),
+						path.MatchRelative().AtParent().AtName("credentials_wo_version"),
+					),
+				},
+			},
+			"credentials_wo_version": schema.Int64Attribute{
+				Description: "Version of the write-only credentials. Terraform can't detect changes of write-only values, so bump the version to send rotated credentials to Funnel.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// azureBlobDestinationValidators returns the rules of the Azure Blob Storage destination at the path.
+func azureBlobDestinationValidators(destination path.Path) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		common.ExactlyOneOfAttributes(destination, "sas_token", "client_secret", "sas_token_wo", "client_secret_wo"),
+		resourcevalidator.RequiredTogether(
+			destination.Expression().AtName("tenant_id"),
+			destination.Expression().AtName("client_id"),
+		),
+		resourcevalidator.PreferWriteOnlyAttribute(
+			destination.Expression().AtName("sas_token"),
+			destination.Expression().AtName("sas_token_wo"),
+		),
+		resourcevalidator.PreferWriteOnlyAttribute(
+			destination.Expression().AtName("client_secret"),
+			destination.Expression().AtName("client_secret_wo"),
+		),
+	}
+}
+
+func getAzureBlobExport(ctx context.Context, client *funnel.Client, accountId string, id string) (*AzureBlobResourceModel, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelAzureBlobJSON](ctx, "exports", client, accountId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate that the export is an Azure Blob Storage export
+	if respObj.Destination.Type != "azure_blob" {
+		return nil, fmt.Errorf("export %s is not an Azure Blob Storage export (type: %s)", id, respObj.Destination.Type)
+	}
+
+	respObj.Fields = respObj.Query.Fields
+	respObj.Range = respObj.Query.Range
+	if respObj.Format.Type == "raw" {
+		respObj.Format.Type = "parquet"
+	}
+	// If not provided, the Exports API sets currency to "*" to pick up the workspace default currency
+	if respObj.Currency == "*" {
+		respObj.Currency = ""
+	}
+	respObj.Filters = common.ConvertFiltersFromMeld(respObj.Query.Where)
+
+	export, err := common.ConvertJSONToTF[FunnelAzureBlobJSON, AzureBlobResourceModel](respObj)
+	if err != nil {
+		return nil, err
+	}
+	export.SetFiltersFromMeld(respObj.Query.Where)
+
+	nullUnsetAuthentication(respObj.Destination.SASToken, &export.Destination.SASToken)
+	nullUnsetAuthentication(respObj.Destination.ClientSecret, &export.Destination.ClientSecret)
+	nullUnsetAuthentication(respObj.Destination.TenantId, &export.Destination.TenantId, &export.Destination.ClientId)
+
+	return &export, nil
+}
+
+// azureBlobWriteOnlyCredentialNames are the credentials of the Azure Blob Storage destination that can be write-only.
+var azureBlobWriteOnlyCredentialNames = []string{"sas_token", "client_secret"}
+
+// withAzureBlobWriteOnlyCredentials returns a copy of the model to send to the API with the write-only SAS token and client secret in place of the stored ones.
+func withAzureBlobWriteOnlyCredentials(model AzureBlobResourceModel, credentials writeOnlyCredentials) AzureBlobResourceModel {
+	model.Destination.SASToken = credentials.replace("sas_token", model.Destination.SASToken)
+	model.Destination.ClientSecret = credentials.replace("client_secret", model.Destination.ClientSecret)
+	return model
+}
+
+// keepAzureBlobWriteOnlyState keeps the prior SAS token and client secret of an Azure Blob Storage destination with write-only credentials.
+func keepAzureBlobWriteOnlyState(destination *ExportAzureBlobDestination, prior ExportAzureBlobDestination) {
+	if keepWriteOnlyVersion(&destination.CredentialsWOVersion, prior.CredentialsWOVersion) {
+		destination.SASToken = prior.SASToken
+		destination.ClientSecret = prior.ClientSecret
+	}
+}
+
+// Mutating the Azure Blob Storage export data before sending to the API with defaults and conversions.
+func prepareAzureBlobExportData(ctx context.Context, data *FunnelAzureBlobJSON, model AzureBlobResourceModel) error {
+	mapped_filters, err := common.ConvertWhereToMeld(data.Filters, model.ExportShared)
+	if err != nil {
+		return err
+	}
+
+	data.OnlyAllowEditFromAPI = true
+	data.Type = "azure_blob"
+	data.Destination.Type = "azure_blob"
+	data.Query = common.QueryJSON{
+		Fields: data.Fields,
+		Range:  data.Range,
+		Where:  mapped_filters,
+	}
+	data.Format.Headers = "safename"
+	if data.Format.Type == "parquet" {
+		data.Format.Type = "raw"
+	}
+
+	return nil
+}
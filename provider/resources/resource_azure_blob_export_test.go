@@ -0,0 +1,126 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"terraform-provider-funnel/provider/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newAzureBlobTestModel() AzureBlobResourceModel {
+	return AzureBlobResourceModel{
+		ExportShared: common.ExportShared{
+			Name:      types.StringValue("test-export"),
+			Workspace: types.StringValue("test-workspace"),
+			Schedule:  types.StringValue("0 6 * * *"),
+			Format: common.ExportFormat{
+				Type:    types.StringValue("csv"),
+				Metrics: types.StringValue("export"),
+			},
+			Range:    common.ExportRange{Start: types.StringValue("2024-01-01")},
+			Timeouts: common.NullTimeouts(),
+		},
+		Destination: ExportAzureBlobDestination{
+			StorageAccount:       types.StringValue("funnelexports"),
+			Container:            types.StringValue("marketing"),
+			PathPrefix:           types.StringValue("exports"),
+			OutputIdTemplate:     types.StringValue("funnel_export_{date}"),
+			TenantId:             types.StringValue("tenant-id"),
+			ClientId:             types.StringValue("client-id"),
+			ClientSecretWO:       types.StringValue("wo-secret"),
+			CredentialsWOVersion: types.Int64Value(1),
+		},
+	}
+}
+
+func TestAzureBlobExport_SendsWriteOnlyCredentials(t *testing.T) {
+	var sent FunnelAzureBlobJSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123"}`))
+	})
+
+	data := newAzureBlobTestModel()
+	credentials := writeOnlyCredentials{
+		"sas_token":     types.StringNull(),
+		"client_secret": types.StringValue("wo-secret"),
+	}
+
+	if _, err := azureBlobExportDestination.createModel(context.Background(), client, withAzureBlobWriteOnlyCredentials(data, credentials)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if sent.Destination.Type != "azure_blob" {
+		t.Errorf("Expected destination type azure_blob, got %q", sent.Destination.Type)
+	}
+	if sent.Destination.ClientSecret != "wo-secret" || sent.Destination.TenantId != "tenant-id" {
+		t.Errorf("Expected the service principal to be sent, got %+v", sent.Destination)
+	}
+	if !data.Destination.ClientSecret.IsNull() {
+		t.Error("Expected the model saved to state to have no client secret")
+	}
+}
+
+func TestAzureBlobExport_Get(t *testing.T) {
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123","destination":{"type":"azure_blob","storageAccount":"funnelexports","container":"marketing","pathPrefix":"exports"}}`))
+	})
+
+	export, err := getAzureBlobExport(context.Background(), client, "test-workspace", "export-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if export.Destination.StorageAccount.ValueString() != "funnelexports" || export.Destination.Container.ValueString() != "marketing" {
+		t.Errorf("Expected the storage account and container from the API, got %+v", export.Destination)
+	}
+	if !export.Destination.TenantId.IsNull() || !export.Destination.SASToken.IsNull() {
+		t.Errorf("Expected no service principal nor SAS token, got %+v", export.Destination)
+	}
+
+	// The write-only client secret stays out of state
+	export.Destination.ClientSecret = types.StringValue("secret")
+	keepAzureBlobWriteOnlyState(&export.Destination, newAzureBlobTestModel().Destination)
+	if !export.Destination.ClientSecret.IsNull() || export.Destination.CredentialsWOVersion.ValueInt64() != 1 {
+		t.Errorf("Expected the write-only credentials state to be kept, got %+v", export.Destination)
+	}
+}
+
+func TestAzureBlobResource_ConfigValidators_Credentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*ExportAzureBlobDestination)
+		wantError bool
+	}{
+		{name: "write-only service principal", configure: func(d *ExportAzureBlobDestination) {}},
+		{
+			name: "sas token",
+			configure: func(d *ExportAzureBlobDestination) {
+				d.TenantId = types.StringNull()
+				d.ClientId = types.StringNull()
+				d.ClientSecretWO = types.StringNull()
+				d.SASToken = types.StringValue("token")
+			},
+		},
+		{name: "no credentials", configure: func(d *ExportAzureBlobDestination) { d.ClientSecretWO = types.StringNull() }, wantError: true},
+		{name: "sas token and client secret", configure: func(d *ExportAzureBlobDestination) { d.SASTokenWO = types.StringValue("token") }, wantError: true},
+		{name: "tenant without client", configure: func(d *ExportAzureBlobDestination) { d.ClientId = types.StringNull() }, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newAzureBlobTestModel()
+			tt.configure(&data.Destination)
+
+			diags := validateResourceConfig(t, newExportDestinationResource(azureBlobExportDestination), data)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
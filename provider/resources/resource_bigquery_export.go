@@ -7,27 +7,48 @@ import (
 	"terraform-provider-funnel/provider/common"
 	"terraform-provider-funnel/provider/funnel"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BigqueryResource{}
 var _ resource.ResourceWithImportState = &BigqueryResource{}
+var _ resource.ResourceWithConfigValidators = &BigqueryResource{}
+var _ resource.ResourceWithModifyPlan = &BigqueryResource{}
 
 func NewBigqueryResource() resource.Resource {
 	return &BigqueryResource{}
 }
 
 type BigqueryResource struct {
-	config *common.FunnelProviderModel
+	client *funnel.Client
 }
 
 type ExportBigqueryDestination struct {
-	OutputIdTemplate types.String `tfsdk:"output_id_template"`
-	DatasetId        types.String `tfsdk:"dataset_id"`
-	ProjectId        types.String `tfsdk:"project_id"`
+	OutputIdTemplate    types.String              `tfsdk:"output_id_template"`
+	DatasetId           types.String              `tfsdk:"dataset_id"`
+	ProjectId           types.String              `tfsdk:"project_id"`
+	SingleTable         types.Bool                `tfsdk:"single_table"`
+	WriteMode           types.String              `tfsdk:"write_mode"`
+	Location            types.String              `tfsdk:"location"`
+	TimePartitioning    *BigqueryTimePartitioning `tfsdk:"time_partitioning"`
+	ClusteringFields    []types.String            `tfsdk:"clustering_fields"`
+	TableExpirationDays types.Int64               `tfsdk:"table_expiration_days"`
+	CredentialsRef      types.String              `tfsdk:"credentials_ref"`
+}
+
+type BigqueryTimePartitioning struct {
+	Field types.String `tfsdk:"field"`
+	Type  types.String `tfsdk:"type"`
 }
 
 type BigqueryResourceModel struct {
@@ -36,11 +57,23 @@ type BigqueryResourceModel struct {
 }
 
 type FunnelBigqueryDestinationJSON struct {
-	Type             string `json:"type"`
-	OutputIdTemplate string `json:"outputIdTemplate"`
-	DatasetId        string `json:"datasetId"`
-	ProjectId        string `json:"projectId"`
-	SingleTable      bool   `json:"singleTable"`
+	Type             string                              `json:"type"`
+	OutputIdTemplate string                              `json:"outputIdTemplate"`
+	DatasetId        string                              `json:"datasetId"`
+	ProjectId        string                              `json:"projectId"`
+	SingleTable      bool                                `json:"singleTable"`
+	WriteMode        string                              `json:"writeMode,omitempty"`
+	Location         string                              `json:"location,omitempty"`
+	TimePartitioning *FunnelBigqueryTimePartitioningJSON `json:"timePartitioning,omitempty"`
+	// Copied by hand since the JSON converter doesn't convert slices of values.
+	ClusteringFields    []string `json:"clusteringFields,omitempty"`
+	TableExpirationDays int64    `json:"tableExpirationDays,omitempty"`
+	CredentialsRef      string   `json:"credentialsRef,omitempty"`
+}
+
+type FunnelBigqueryTimePartitioningJSON struct {
+	Field string `json:"field"`
+	Type  string `json:"type"`
 }
 
 type FunnelBigqueryJSON struct {
@@ -53,7 +86,12 @@ func (r *BigqueryResource) Metadata(ctx context.Context, req resource.MetadataRe
 }
 
 func (r *BigqueryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = common.GetExportSchema(schema.SingleNestedAttribute{
+	resp.Schema = common.GetExportSchema(ctx, bigqueryDestinationAttribute(), "BigQuery export")
+}
+
+// bigqueryDestinationAttribute is the BigQuery destination, shared with the bigquery block of funnel_export.
+func bigqueryDestinationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Bigquery destination table",
 		Required:            true,
 		Attributes: map[string]schema.Attribute{
@@ -72,8 +110,79 @@ func (r *BigqueryResource) Schema(ctx context.Context, req resource.SchemaReques
 				Description:         "BigQuery project ID",
 				Required:            true,
 			},
+			"single_table": schema.BoolAttribute{
+				MarkdownDescription: "Whether to export to a single table instead of one table per export run. Defaults to `true`",
+				Description:         "Whether to export to a single table instead of one table per export run. Defaults to true",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"write_mode": schema.StringAttribute{
+				MarkdownDescription: "How an export run writes to the table: `replace` the table, `append` the rows or `merge_by_date` to replace the rows of the exported dates. Funnel picks it when not set, and removing it keeps the mode the export has",
+				Description:         "How an export run writes to the table: replace the table, append the rows or merge_by_date to replace the rows of the exported dates. Funnel picks it when not set, and removing it keeps the mode the export has",
+				Optional:            true,
+				Computed:            true,
+				// Funnel's default mode isn't known to the provider, so a removed mode keeps the value in state
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("replace", "append", "merge_by_date"),
+				},
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "BigQuery location of the dataset, e.g. `EU`, `US` or `europe-west1`",
+				Description:         "BigQuery location of the dataset, e.g. EU, US or europe-west1",
+				Optional:            true,
+			},
+			"time_partitioning": schema.SingleNestedAttribute{
+				MarkdownDescription: "Time partitioning of the table on a date column",
+				Description:         "Time partitioning of the table on a date column",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"field": schema.StringAttribute{
+						MarkdownDescription: "Date column to partition the table by",
+						Description:         "Date column to partition the table by",
+						Required:            true,
+					},
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Granularity of the partitions (`DAY`, `MONTH` or `YEAR`)",
+						Description:         "Granularity of the partitions (DAY, MONTH or YEAR)",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("DAY", "MONTH", "YEAR"),
+						},
+					},
+				},
+			},
+			"clustering_fields": schema.ListAttribute{
+				MarkdownDescription: "Columns to cluster the table by, in order. BigQuery allows up to four",
+				Description:         "Columns to cluster the table by, in order. BigQuery allows up to four",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 4),
+				},
+			},
+			"table_expiration_days": schema.Int64Attribute{
+				MarkdownDescription: "Number of days after which BigQuery deletes the tables of the export",
+				Description:         "Number of days after which BigQuery deletes the tables of the export",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"credentials_ref": schema.StringAttribute{
+				MarkdownDescription: "Reference to the credentials of a customer-owned service account to write to the dataset with",
+				Description:         "Reference to the credentials of a customer-owned service account to write to the dataset with",
+				Optional:            true,
+			},
 		},
-	}, "BigQuery export")
+	}
+}
+
+func (r *BigqueryResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return common.ExportConfigValidators()
 }
 
 func (r *BigqueryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -82,18 +191,22 @@ func (r *BigqueryResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	config, ok := req.ProviderData.(*common.FunnelProviderModel)
+	client, ok := req.ProviderData.(*funnel.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *FunnelProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.config = config
+	r.client = client
+}
+
+func (r *BigqueryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(verifyExportFields(ctx, r.client, &resp.Plan)...)
 }
 
 func (r *BigqueryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -105,6 +218,20 @@ func (r *BigqueryResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, data.Workspace.ValueString(), data.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -112,7 +239,7 @@ func (r *BigqueryResource) Create(ctx context.Context, req resource.CreateReques
 	// Create the export via API
 	respObj, err := createBigqueryExport(
 		ctx,
-		r.config,
+		r.client,
 		data,
 	)
 	if err != nil {
@@ -132,8 +259,9 @@ func (r *BigqueryResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Set the ID from the API response
+	// Set the ID and the values defaulted by Funnel from the API response
 	data.Id = types.StringValue(respObj.Id)
+	setBigqueryDestinationFromAPI(&data.Destination, respObj.Destination)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -149,9 +277,18 @@ func (r *BigqueryResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	export, err := getBigqueryExport(
 		ctx,
-		r.config,
+		r.client,
 		data.Workspace.ValueString(),
 		data.Id.ValueString(),
 	)
@@ -172,6 +309,9 @@ func (r *BigqueryResource) Read(ctx context.Context, req resource.ReadRequest, r
 	// Merge API response with state - preserve ID and workspace, prefer API values for everything else
 	export.Id = data.Id
 	export.Workspace = data.Workspace
+	export.Timeouts = data.Timeouts
+	export.KeepScheduleConfig(data.ExportShared)
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(data.ExportShared)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &export)...)
@@ -186,13 +326,27 @@ func (r *BigqueryResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := updateBigqueryExport(
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, data.Workspace.ValueString(), data.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respObj, err := updateBigqueryExport(
 		ctx,
-		r.config,
+		r.client,
 		data,
 	)
 	if err != nil {
@@ -203,6 +357,8 @@ func (r *BigqueryResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	setBigqueryDestinationFromAPI(&data.Destination, respObj.Destination)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -220,7 +376,7 @@ func (r *BigqueryResource) ImportState(ctx context.Context, req resource.ImportS
 	workspaceID := idParts[0]
 	exportID := idParts[1]
 
-	export, err := getBigqueryExport(ctx, r.config, workspaceID, exportID)
+	export, err := getBigqueryExport(ctx, r.client, workspaceID, exportID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Importing BigQuery Export",
@@ -231,6 +387,8 @@ func (r *BigqueryResource) ImportState(ctx context.Context, req resource.ImportS
 
 	export.Id = types.StringValue(exportID)
 	export.Workspace = types.StringValue(workspaceID)
+	export.Timeouts = common.NullTimeouts()
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(common.ExportShared{})...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, export)...)
 }
@@ -245,10 +403,19 @@ func (r *BigqueryResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the export via API
 	err := deleteBigqueryExport(
 		ctx,
-		r.config,
+		r.client,
 		data.Workspace.ValueString(),
 		data.Id.ValueString(),
 	)
@@ -261,8 +428,8 @@ func (r *BigqueryResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
-func getBigqueryExport(ctx context.Context, config *common.FunnelProviderModel, accountId string, id string) (*BigqueryResourceModel, error) {
-	respObj, err := funnel.GetWorkspaceEntity[FunnelBigqueryJSON](ctx, "exports", config, accountId, id)
+func getBigqueryExport(ctx context.Context, client *funnel.Client, accountId string, id string) (*BigqueryResourceModel, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelBigqueryJSON](ctx, "exports", client, accountId, id)
 	if err != nil {
 		return nil, err
 	}
@@ -288,44 +455,82 @@ func getBigqueryExport(ctx context.Context, config *common.FunnelProviderModel,
 	if err != nil {
 		return nil, err
 	}
+	export.SetFiltersFromMeld(respObj.Query.Where)
+
+	// The optional settings are left out by the API when they aren't set
+	setBigqueryDestinationFromAPI(&export.Destination, respObj.Destination)
+	if respObj.Destination.Location == "" {
+		export.Destination.Location = types.StringNull()
+	}
+	if respObj.Destination.TableExpirationDays == 0 {
+		export.Destination.TableExpirationDays = types.Int64Null()
+	}
+	if respObj.Destination.CredentialsRef == "" {
+		export.Destination.CredentialsRef = types.StringNull()
+	}
+	export.Destination.ClusteringFields = nil
+	for _, field := range respObj.Destination.ClusteringFields {
+		export.Destination.ClusteringFields = append(export.Destination.ClusteringFields, types.StringValue(field))
+	}
 
 	return &export, nil
 }
 
-func createBigqueryExport(ctx context.Context, config *common.FunnelProviderModel, model BigqueryResourceModel) (FunnelBigqueryJSON, *funnel.APIError) {
+func createBigqueryExport(ctx context.Context, client *funnel.Client, model BigqueryResourceModel) (FunnelBigqueryJSON, *funnel.APIError) {
 	data, err := common.ConvertTFToJSON[BigqueryResourceModel, FunnelBigqueryJSON](model)
 	if err != nil {
 		return FunnelBigqueryJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
 	}
 
-	prepareBigqueryExportData(&data, model)
+	if err := prepareBigqueryExportData(&data, model); err != nil {
+		return FunnelBigqueryJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
+	}
 
-	return funnel.CreateWorkspaceEntity[FunnelBigqueryJSON, FunnelBigqueryJSON](ctx, "exports", config, model.Workspace.ValueString(), data)
+	return funnel.CreateWorkspaceEntity[FunnelBigqueryJSON, FunnelBigqueryJSON](ctx, "exports", client, model.Workspace.ValueString(), data)
 }
 
-func updateBigqueryExport(ctx context.Context, config *common.FunnelProviderModel, model BigqueryResourceModel) (FunnelBigqueryJSON, error) {
+func updateBigqueryExport(ctx context.Context, client *funnel.Client, model BigqueryResourceModel) (FunnelBigqueryJSON, error) {
 	data, err := common.ConvertTFToJSON[BigqueryResourceModel, FunnelBigqueryJSON](model)
 	if err != nil {
 		return FunnelBigqueryJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
 	}
 
-	prepareBigqueryExportData(&data, model)
+	if err := prepareBigqueryExportData(&data, model); err != nil {
+		return FunnelBigqueryJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
+	}
 
-	return funnel.UpdateWorkspaceEntity[FunnelBigqueryJSON, FunnelBigqueryJSON](ctx, "exports", config, model.Workspace.ValueString(), model.Id.ValueString(), data)
+	return funnel.UpdateWorkspaceEntity[FunnelBigqueryJSON, FunnelBigqueryJSON](ctx, "exports", client, model.Workspace.ValueString(), model.Id.ValueString(), data)
 }
 
-func deleteBigqueryExport(ctx context.Context, config *common.FunnelProviderModel, accountId string, id string) error {
-	return funnel.DeleteWorkspaceEntity(ctx, "exports", config, accountId, id)
+func deleteBigqueryExport(ctx context.Context, client *funnel.Client, accountId string, id string) error {
+	return funnel.DeleteWorkspaceEntity(ctx, "exports", client, accountId, id)
+}
+
+// setBigqueryDestinationFromAPI sets the destination attributes that Funnel defaults from the destination returned by the API.
+func setBigqueryDestinationFromAPI(destination *ExportBigqueryDestination, respObj FunnelBigqueryDestinationJSON) {
+	destination.SingleTable = types.BoolValue(respObj.SingleTable)
+	destination.WriteMode = types.StringValue(respObj.WriteMode)
+	if respObj.WriteMode == "" {
+		destination.WriteMode = types.StringNull()
+	}
 }
 
 // Mutating the BigQuery export data before sending to the API with defaults and conversions.
-func prepareBigqueryExportData(data *FunnelBigqueryJSON, model BigqueryResourceModel) {
-	mapped_filters := common.ConvertFiltersToMeld(data.Filters)
+func prepareBigqueryExportData(data *FunnelBigqueryJSON, model BigqueryResourceModel) error {
+	mapped_filters, err := common.ConvertWhereToMeld(data.Filters, model.ExportShared)
+	if err != nil {
+		return err
+	}
 
 	data.OnlyAllowEditFromAPI = true
 	data.Type = "bigquery"
 	data.Destination.Type = "bigquery"
-	data.Destination.SingleTable = true
+	// Exports go to a single table unless single_table is set to false
+	data.Destination.SingleTable = model.Destination.SingleTable.IsNull() || model.Destination.SingleTable.IsUnknown() || model.Destination.SingleTable.ValueBool()
+	data.Destination.ClusteringFields = nil
+	for _, field := range model.Destination.ClusteringFields {
+		data.Destination.ClusteringFields = append(data.Destination.ClusteringFields, field.ValueString())
+	}
 	data.Query = common.QueryJSON{
 		Fields: data.Fields,
 		Range:  data.Range,
@@ -335,4 +540,6 @@ func prepareBigqueryExportData(data *FunnelBigqueryJSON, model BigqueryResourceM
 	if data.Format.Type == "parquet" {
 		data.Format.Type = "raw"
 	}
+
+	return nil
 }
@@ -2,12 +2,15 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"terraform-provider-funnel/provider/auth"
 	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -134,12 +137,12 @@ func TestBigqueryResource_Create_Conflict409(t *testing.T) {
 	}))
 	defer mockServer.Close()
 
-	// Create a mock config with the mock server URL as the environment
-	config := &common.FunnelProviderModel{
-		Environment:    types.StringValue(mockServer.URL + "/v1"),
-		SubscriptionId: types.StringValue("test-subscription-id"),
-		Token:          "test-token",
-	}
+	// Create a mock client with the mock server URL as the environment
+	client := funnel.NewClient(funnel.ClientConfig{
+		Environment:    mockServer.URL + "/v1",
+		SubscriptionId: "test-subscription-id",
+		TokenSource:    auth.StaticTokenSource("test-token"),
+	})
 
 	sharedData := common.ExportShared{
 		Name:      types.StringValue("test-export"),
@@ -179,7 +182,7 @@ func TestBigqueryResource_Create_Conflict409(t *testing.T) {
 
 	_, err := createBigqueryExport(
 		context.Background(),
-		config,
+		client,
 		data,
 	)
 
@@ -198,3 +201,127 @@ func TestBigqueryResource_Create_Conflict409(t *testing.T) {
 		t.Error("Expected error message to be populated")
 	}
 }
+
+func newBigqueryTestModel() BigqueryResourceModel {
+	return BigqueryResourceModel{
+		ExportShared: common.ExportShared{
+			Name:      types.StringValue("test-export"),
+			Workspace: types.StringValue("test-workspace"),
+			Schedule:  types.StringValue("0 6 * * *"),
+			Format: common.ExportFormat{
+				Type:    types.StringValue("csv"),
+				Metrics: types.StringValue("export"),
+			},
+			Range:    common.ExportRange{Start: types.StringValue("2024-01-01")},
+			Timeouts: common.NullTimeouts(),
+		},
+		Destination: ExportBigqueryDestination{
+			OutputIdTemplate:    types.StringValue("test_table"),
+			DatasetId:           types.StringValue("test_dataset"),
+			ProjectId:           types.StringValue("test_project"),
+			SingleTable:         types.BoolUnknown(),
+			WriteMode:           types.StringUnknown(),
+			Location:            types.StringNull(),
+			TableExpirationDays: types.Int64Null(),
+			CredentialsRef:      types.StringNull(),
+		},
+	}
+}
+
+func TestBigqueryExport_Create_Options(t *testing.T) {
+	var sent FunnelBigqueryJSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123"}`))
+	})
+
+	if _, err := createBigqueryExport(context.Background(), client, newBigqueryTestModel()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !sent.Destination.SingleTable || sent.Destination.WriteMode != "" {
+		t.Errorf("Expected a single table and no write mode by default, got %v and %q", sent.Destination.SingleTable, sent.Destination.WriteMode)
+	}
+	if sent.Destination.TimePartitioning != nil || sent.Destination.ClusteringFields != nil {
+		t.Errorf("Expected no partitioning nor clustering by default, got %v and %v", sent.Destination.TimePartitioning, sent.Destination.ClusteringFields)
+	}
+
+	data := newBigqueryTestModel()
+	data.Destination.SingleTable = types.BoolValue(false)
+	data.Destination.WriteMode = types.StringValue("merge_by_date")
+	data.Destination.Location = types.StringValue("EU")
+	data.Destination.TimePartitioning = &BigqueryTimePartitioning{Field: types.StringValue("date"), Type: types.StringValue("DAY")}
+	data.Destination.ClusteringFields = []types.String{types.StringValue("campaign_name"), types.StringValue("source")}
+	data.Destination.TableExpirationDays = types.Int64Value(90)
+	data.Destination.CredentialsRef = types.StringValue("bigquery-credentials")
+
+	if _, err := createBigqueryExport(context.Background(), client, data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent.Destination.SingleTable || sent.Destination.WriteMode != "merge_by_date" || sent.Destination.Location != "EU" {
+		t.Errorf("Expected the table layout, write mode and location to be sent, got %+v", sent.Destination)
+	}
+	if sent.Destination.TimePartitioning == nil || sent.Destination.TimePartitioning.Field != "date" || sent.Destination.TimePartitioning.Type != "DAY" {
+		t.Errorf("Expected daily partitioning on date, got %v", sent.Destination.TimePartitioning)
+	}
+	if strings.Join(sent.Destination.ClusteringFields, ",") != "campaign_name,source" {
+		t.Errorf("Expected the clustering fields in order, got %v", sent.Destination.ClusteringFields)
+	}
+	if sent.Destination.TableExpirationDays != 90 || sent.Destination.CredentialsRef != "bigquery-credentials" {
+		t.Errorf("Expected the expiration and credentials to be sent, got %d and %q", sent.Destination.TableExpirationDays, sent.Destination.CredentialsRef)
+	}
+}
+
+func TestBigqueryExport_Get_Options(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		check       func(*testing.T, ExportBigqueryDestination)
+	}{
+		{
+			name:        "all options",
+			destination: `{"type": "bigquery", "outputIdTemplate": "test_table", "datasetId": "test_dataset", "projectId": "test_project", "singleTable": false, "writeMode": "append", "location": "EU", "timePartitioning": {"field": "date", "type": "MONTH"}, "clusteringFields": ["campaign_name"], "tableExpirationDays": 30, "credentialsRef": "bigquery-credentials"}`,
+			check: func(t *testing.T, d ExportBigqueryDestination) {
+				if d.SingleTable.ValueBool() || d.WriteMode.ValueString() != "append" || d.Location.ValueString() != "EU" {
+					t.Errorf("Expected the table layout, write mode and location from the API, got %v, %v and %v", d.SingleTable, d.WriteMode, d.Location)
+				}
+				if d.TimePartitioning == nil || d.TimePartitioning.Field.ValueString() != "date" || d.TimePartitioning.Type.ValueString() != "MONTH" {
+					t.Errorf("Expected monthly partitioning on date, got %v", d.TimePartitioning)
+				}
+				if len(d.ClusteringFields) != 1 || d.ClusteringFields[0].ValueString() != "campaign_name" {
+					t.Errorf("Expected the clustering fields from the API, got %v", d.ClusteringFields)
+				}
+				if d.TableExpirationDays.ValueInt64() != 30 || d.CredentialsRef.ValueString() != "bigquery-credentials" {
+					t.Errorf("Expected the expiration and credentials from the API, got %v and %v", d.TableExpirationDays, d.CredentialsRef)
+				}
+			},
+		},
+		{
+			name:        "no options",
+			destination: `{"type": "bigquery", "outputIdTemplate": "test_table", "datasetId": "test_dataset", "projectId": "test_project", "singleTable": true}`,
+			check: func(t *testing.T, d ExportBigqueryDestination) {
+				if !d.SingleTable.ValueBool() || !d.WriteMode.IsNull() {
+					t.Errorf("Expected a single table and no write mode, got %v and %v", d.SingleTable, d.WriteMode)
+				}
+				if !d.Location.IsNull() || d.TimePartitioning != nil || d.ClusteringFields != nil || !d.TableExpirationDays.IsNull() || !d.CredentialsRef.IsNull() {
+					t.Errorf("Expected the unset options to be null, got %+v", d)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id": "export-123", "name": "test-export", "format": {"type": "csv", "metrics": "export"}, "destination": ` + tt.destination + `}`))
+			})
+
+			export, err := getBigqueryExport(context.Background(), client, "test-workspace", "export-123")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			tt.check(t, export.Destination)
+		})
+	}
+}
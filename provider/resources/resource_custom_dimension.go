@@ -9,6 +9,7 @@ import (
 	"terraform-provider-funnel/provider/common"
 	"terraform-provider-funnel/provider/funnel"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -27,15 +28,16 @@ func NewCustomDimensionResource() resource.Resource {
 }
 
 type CustomDimensionResource struct {
-	config *common.FunnelProviderModel
+	client *funnel.Client
 }
 
 type CustomDimensionResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	Workspace   types.String `tfsdk:"workspace"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Unit        types.String `tfsdk:"unit"`
+	Id          types.String   `tfsdk:"id"`
+	Workspace   types.String   `tfsdk:"workspace"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Unit        types.String   `tfsdk:"unit"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 type FunnelCustomDimensionJSON struct {
@@ -83,6 +85,9 @@ func (r *CustomDimensionResource) Schema(ctx context.Context, req resource.Schem
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -91,16 +96,16 @@ func (r *CustomDimensionResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
-	config, ok := req.ProviderData.(*common.FunnelProviderModel)
+	client, ok := req.ProviderData.(*funnel.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *FunnelProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.config = config
+	r.client = client
 }
 
 func (r *CustomDimensionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -110,6 +115,15 @@ func (r *CustomDimensionResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	payload := FunnelCustomDimensionJSON{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
@@ -117,7 +131,7 @@ func (r *CustomDimensionResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	tflog.Info(ctx, "Creating custom dimension", map[string]any{"name": payload.Name})
-	respObj, apiErr := funnel.CreateWorkspaceEntity[FunnelCustomDimensionJSON, FunnelCustomDimensionJSON](ctx, "custom-fields", r.config, data.Workspace.ValueString(), payload)
+	respObj, apiErr := funnel.CreateWorkspaceEntity[FunnelCustomDimensionJSON, FunnelCustomDimensionJSON](ctx, "custom-fields", r.client, data.Workspace.ValueString(), payload)
 	if apiErr != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Custom Dimension",
@@ -143,8 +157,17 @@ func (r *CustomDimensionResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "Reading custom dimension", map[string]any{"id": data.Id.ValueString()})
-	respObj, err := funnel.GetWorkspaceEntity[FunnelCustomDimensionJSON](ctx, "custom-fields", r.config, data.Workspace.ValueString(), data.Id.ValueString())
+	respObj, err := funnel.GetWorkspaceEntity[FunnelCustomDimensionJSON](ctx, "custom-fields", r.client, data.Workspace.ValueString(), data.Id.ValueString())
 	if err != nil {
 		var apiErr funnel.APIError
 		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
@@ -173,6 +196,15 @@ func (r *CustomDimensionResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	payload := FunnelCustomDimensionJSON{
 		Id:          data.Id.ValueString(),
 		Name:        data.Name.ValueString(),
@@ -181,7 +213,7 @@ func (r *CustomDimensionResource) Update(ctx context.Context, req resource.Updat
 	}
 
 	tflog.Info(ctx, "Updating custom dimension", map[string]any{"id": data.Id.ValueString(), "name": payload.Name})
-	_, err := funnel.UpdateWorkspaceEntity[FunnelCustomDimensionJSON, FunnelCustomDimensionJSON](ctx, "custom-fields", r.config, data.Workspace.ValueString(), data.Id.ValueString(), payload)
+	_, err := funnel.UpdateWorkspaceEntity[FunnelCustomDimensionJSON, FunnelCustomDimensionJSON](ctx, "custom-fields", r.client, data.Workspace.ValueString(), data.Id.ValueString(), payload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Custom Dimension",
@@ -200,8 +232,17 @@ func (r *CustomDimensionResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "Deleting custom dimension", map[string]any{"id": data.Id.ValueString()})
-	err := funnel.DeleteWorkspaceEntity(ctx, "custom-fields", r.config, data.Workspace.ValueString(), data.Id.ValueString())
+	err := funnel.DeleteWorkspaceEntity(ctx, "custom-fields", r.client, data.Workspace.ValueString(), data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Custom Dimension",
@@ -225,7 +266,7 @@ func (r *CustomDimensionResource) ImportState(ctx context.Context, req resource.
 	customDimensionID := idParts[1]
 
 	tflog.Info(ctx, "Importing custom dimension", map[string]any{"id": customDimensionID, "workspace": workspaceID})
-	respObj, err := funnel.GetWorkspaceEntity[FunnelCustomDimensionJSON](ctx, "custom-fields", r.config, workspaceID, customDimensionID)
+	respObj, err := funnel.GetWorkspaceEntity[FunnelCustomDimensionJSON](ctx, "custom-fields", r.client, workspaceID, customDimensionID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Custom Dimension",
@@ -240,6 +281,7 @@ func (r *CustomDimensionResource) ImportState(ctx context.Context, req resource.
 		Name:        types.StringValue(respObj.Name),
 		Description: types.StringValue(respObj.Description),
 		Unit:        types.StringValue(respObj.Unit),
+		Timeouts:    common.NullTimeouts(),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -9,6 +9,7 @@ import (
 	"terraform-provider-funnel/provider/common"
 	"terraform-provider-funnel/provider/funnel"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -28,17 +29,18 @@ func NewCustomMetricResource() resource.Resource {
 }
 
 type CustomMetricResource struct {
-	config *common.FunnelProviderModel
+	client *funnel.Client
 }
 
 type CustomMetricResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	Workspace   types.String `tfsdk:"workspace"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Aggregation types.String `tfsdk:"aggregation"`
-	Unit        types.String `tfsdk:"unit"`
-	Precision   types.Int64  `tfsdk:"precision"`
+	Id          types.String   `tfsdk:"id"`
+	Workspace   types.String   `tfsdk:"workspace"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Aggregation types.String   `tfsdk:"aggregation"`
+	Unit        types.String   `tfsdk:"unit"`
+	Precision   types.Int64    `tfsdk:"precision"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 type FunnelCustomMetricJSON struct {
@@ -102,6 +104,9 @@ func (r *CustomMetricResource) Schema(ctx context.Context, req resource.SchemaRe
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -110,16 +115,16 @@ func (r *CustomMetricResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	config, ok := req.ProviderData.(*common.FunnelProviderModel)
+	client, ok := req.ProviderData.(*funnel.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *FunnelProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.config = config
+	r.client = client
 }
 
 func (r *CustomMetricResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -129,6 +134,15 @@ func (r *CustomMetricResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	payload := FunnelCustomMetricJSON{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
@@ -138,7 +152,7 @@ func (r *CustomMetricResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	tflog.Info(ctx, "Creating custom metric", map[string]any{"name": payload.Name})
-	respObj, apiErr := funnel.CreateWorkspaceEntity[FunnelCustomMetricJSON, FunnelCustomMetricJSON](ctx, "custom-fields", r.config, data.Workspace.ValueString(), payload)
+	respObj, apiErr := funnel.CreateWorkspaceEntity[FunnelCustomMetricJSON, FunnelCustomMetricJSON](ctx, "custom-fields", r.client, data.Workspace.ValueString(), payload)
 	if apiErr != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Custom Metric",
@@ -166,8 +180,17 @@ func (r *CustomMetricResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "Reading custom metric", map[string]any{"id": data.Id.ValueString()})
-	respObj, err := funnel.GetWorkspaceEntity[FunnelCustomMetricJSON](ctx, "custom-fields", r.config, data.Workspace.ValueString(), data.Id.ValueString())
+	respObj, err := funnel.GetWorkspaceEntity[FunnelCustomMetricJSON](ctx, "custom-fields", r.client, data.Workspace.ValueString(), data.Id.ValueString())
 	if err != nil {
 		var apiErr funnel.APIError
 		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
@@ -198,6 +221,15 @@ func (r *CustomMetricResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	payload := FunnelCustomMetricJSON{
 		Id:          data.Id.ValueString(),
 		Name:        data.Name.ValueString(),
@@ -208,7 +240,7 @@ func (r *CustomMetricResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	tflog.Info(ctx, "Updating custom metric", map[string]any{"id": data.Id.ValueString(), "name": payload.Name})
-	_, err := funnel.UpdateWorkspaceEntity[FunnelCustomMetricJSON, FunnelCustomMetricJSON](ctx, "custom-fields", r.config, data.Workspace.ValueString(), data.Id.ValueString(), payload)
+	_, err := funnel.UpdateWorkspaceEntity[FunnelCustomMetricJSON, FunnelCustomMetricJSON](ctx, "custom-fields", r.client, data.Workspace.ValueString(), data.Id.ValueString(), payload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Custom Metric",
@@ -227,8 +259,17 @@ func (r *CustomMetricResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "Deleting custom metric", map[string]any{"id": data.Id.ValueString()})
-	err := funnel.DeleteWorkspaceEntity(ctx, "custom-fields", r.config, data.Workspace.ValueString(), data.Id.ValueString())
+	err := funnel.DeleteWorkspaceEntity(ctx, "custom-fields", r.client, data.Workspace.ValueString(), data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Custom Metric",
@@ -252,7 +293,7 @@ func (r *CustomMetricResource) ImportState(ctx context.Context, req resource.Imp
 	customMetricID := idParts[1]
 
 	tflog.Info(ctx, "Importing custom metric", map[string]any{"id": customMetricID, "workspace": workspaceID})
-	respObj, err := funnel.GetWorkspaceEntity[FunnelCustomMetricJSON](ctx, "custom-fields", r.config, workspaceID, customMetricID)
+	respObj, err := funnel.GetWorkspaceEntity[FunnelCustomMetricJSON](ctx, "custom-fields", r.client, workspaceID, customMetricID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Custom Metric",
@@ -269,6 +310,7 @@ func (r *CustomMetricResource) ImportState(ctx context.Context, req resource.Imp
 		Aggregation: types.StringValue(respObj.Aggregation),
 		Unit:        types.StringValue(respObj.Unit),
 		Precision:   types.Int64Value(int64(respObj.Precision)),
+		Timeouts:    common.NullTimeouts(),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -9,6 +9,7 @@ import (
 	"terraform-provider-funnel/provider/common"
 	"terraform-provider-funnel/provider/funnel"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -28,20 +29,21 @@ func NewDataSourceResource() resource.Resource {
 }
 
 type DataSourceResource struct {
-	config *common.FunnelProviderModel
+	client *funnel.Client
 }
 
 type DataSourceResourceModel struct {
-	Id               types.String `tfsdk:"id"`
-	Workspace        types.String `tfsdk:"workspace"`
-	Type             types.String `tfsdk:"type"`
-	Name             types.String `tfsdk:"name"`
-	DownloadDisabled types.Bool   `tfsdk:"download_disabled"`
-	RemoteId         types.String `tfsdk:"remote_id"`
-	ExcludeFromMeld  types.Bool   `tfsdk:"exclude_data_from_funnel"`
-	State            types.String `tfsdk:"state"`
-	CredentialId     types.String `tfsdk:"credential_id"`
-	ReportType       types.String `tfsdk:"report_type"`
+	Id               types.String   `tfsdk:"id"`
+	Workspace        types.String   `tfsdk:"workspace"`
+	Type             types.String   `tfsdk:"type"`
+	Name             types.String   `tfsdk:"name"`
+	DownloadDisabled types.Bool     `tfsdk:"download_disabled"`
+	RemoteId         types.String   `tfsdk:"remote_id"`
+	ExcludeFromMeld  types.Bool     `tfsdk:"exclude_data_from_funnel"`
+	State            types.String   `tfsdk:"state"`
+	CredentialId     types.String   `tfsdk:"credential_id"`
+	ReportType       types.String   `tfsdk:"report_type"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 type DataSourceJSON struct {
@@ -156,6 +158,9 @@ func (r *DataSourceResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -163,15 +168,15 @@ func (r *DataSourceResource) Configure(ctx context.Context, req resource.Configu
 	if req.ProviderData == nil {
 		return
 	}
-	config, ok := req.ProviderData.(*common.FunnelProviderModel)
+	client, ok := req.ProviderData.(*funnel.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *FunnelProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	r.config = config
+	r.client = client
 }
 
 func (r *DataSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -181,6 +186,15 @@ func (r *DataSourceResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	payload := CreateDataSourceRequest{
 		FunnelAccountId: data.Workspace.ValueString(),
 		Type:            data.Type.ValueString(),
@@ -204,7 +218,7 @@ func (r *DataSourceResource) Create(ctx context.Context, req resource.CreateRequ
 		payload.ReportType = data.ReportType.ValueString()
 	}
 
-	respObj, err := funnel.CreateWorkspaceEntity[CreateDataSourceRequest, DataSourceJSON](ctx, "datasources", r.config, data.Workspace.ValueString(), payload)
+	respObj, err := funnel.CreateWorkspaceEntity[CreateDataSourceRequest, DataSourceJSON](ctx, "datasources", r.client, data.Workspace.ValueString(), payload)
 	if err != nil {
 		if err.StatusCode == 409 {
 			resp.Diagnostics.AddError(
@@ -250,7 +264,16 @@ func (r *DataSourceResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	ds, err := funnel.GetWorkspaceEntity[DataSourceJSON](ctx, "datasources", r.config, data.Workspace.ValueString(), data.Id.ValueString())
+	readTimeout, diags := data.Timeouts.Read(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ds, err := funnel.GetWorkspaceEntity[DataSourceJSON](ctx, "datasources", r.client, data.Workspace.ValueString(), data.Id.ValueString())
 	if err != nil {
 		var apiErr funnel.APIError
 		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
@@ -293,6 +316,15 @@ func (r *DataSourceResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	payload := UpdateDataSourceRequest{}
 
 	// Only include fields that are being updated
@@ -316,7 +348,7 @@ func (r *DataSourceResource) Update(ctx context.Context, req resource.UpdateRequ
 		payload.DownloadDisabled = &downloadDisabled
 	}
 
-	respObj, err := funnel.PatchWorkspaceEntity[UpdateDataSourceRequest, DataSourceJSON](ctx, "datasources", r.config, data.Workspace.ValueString(), data.Id.ValueString(), payload)
+	respObj, err := funnel.PatchWorkspaceEntity[UpdateDataSourceRequest, DataSourceJSON](ctx, "datasources", r.client, data.Workspace.ValueString(), data.Id.ValueString(), payload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Data Source",
@@ -354,12 +386,21 @@ func (r *DataSourceResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "Deleting data source", map[string]any{
 		"id":        data.Id.ValueString(),
 		"workspace": data.Workspace.ValueString(),
 	})
 
-	err := funnel.DeleteWorkspaceEntity(ctx, "datasources", r.config, data.Workspace.ValueString(), data.Id.ValueString())
+	err := funnel.DeleteWorkspaceEntity(ctx, "datasources", r.client, data.Workspace.ValueString(), data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Data Source",
@@ -381,7 +422,7 @@ func (r *DataSourceResource) ImportState(ctx context.Context, req resource.Impor
 	workspaceID := idParts[0]
 	dataSourceID := idParts[1]
 
-	ds, err := funnel.GetWorkspaceEntity[DataSourceJSON](ctx, "datasources", r.config, workspaceID, dataSourceID)
+	ds, err := funnel.GetWorkspaceEntity[DataSourceJSON](ctx, "datasources", r.client, workspaceID, dataSourceID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Data Source",
@@ -414,6 +455,7 @@ func (r *DataSourceResource) ImportState(ctx context.Context, req resource.Impor
 		State:            types.StringValue(ds.State),
 		CredentialId:     credentialId,
 		RemoteId:         remoteId,
+		Timeouts:         common.NullTimeouts(),
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
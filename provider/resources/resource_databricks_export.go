@@ -0,0 +1,238 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// databricksExportDestination is the Databricks destination of funnel_databricks_export and funnel_export.
+var databricksExportDestination = exportDestinationAdapter[DatabricksResourceModel, ExportDatabricksDestination, FunnelDatabricksJSON]{
+	name:        "databricks",
+	apiType:     "databricks",
+	schema:      databricksDestinationAttribute,
+	validators:  databricksDestinationValidators,
+	title:       "Databricks",
+	description: "Databricks export to a Unity Catalog table through a SQL warehouse.",
+	newModel: func(shared common.ExportShared, destination ExportDatabricksDestination) DatabricksResourceModel {
+		return DatabricksResourceModel{ExportShared: shared, Destination: destination}
+	},
+	parts: func(model DatabricksResourceModel) (common.ExportShared, ExportDatabricksDestination) {
+		return model.ExportShared, model.Destination
+	},
+	exportId:        func(respObj FunnelDatabricksJSON) string { return respObj.Id },
+	get:             getDatabricksExport,
+	prepare:         prepareDatabricksExportData,
+	credentialNames: databricksWriteOnlyCredentialNames,
+	withCredentials: withDatabricksWriteOnlyCredentials,
+	keepState:       keepDatabricksWriteOnlyState,
+}
+
+func NewDatabricksResource() resource.Resource {
+	return newExportDestinationResource(databricksExportDestination)
+}
+
+type ExportDatabricksDestination struct {
+	Host                 types.String `tfsdk:"host"`
+	Catalog              types.String `tfsdk:"catalog"`
+	SchemaName           types.String `tfsdk:"schema_name"`
+	TableName            types.String `tfsdk:"table_name"`
+	WarehouseId          types.String `tfsdk:"warehouse_id"`
+	AccessToken          types.String `tfsdk:"access_token"`
+	AccessTokenWO        types.String `tfsdk:"access_token_wo"`
+	CredentialsWOVersion types.Int64  `tfsdk:"credentials_wo_version"`
+}
+
+type DatabricksResourceModel struct {
+	Destination ExportDatabricksDestination `tfsdk:"destination"`
+	common.ExportShared
+}
+
+type FunnelDatabricksDestinationJSON struct {
+	Type        string `json:"type"`
+	Host        string `json:"host"`
+	Catalog     string `json:"catalog"`
+	SchemaName  string `json:"schemaName"`
+	TableName   string `json:"tableName"`
+	WarehouseId string `json:"warehouseId"`
+	AccessToken string `json:"accessToken,omitempty"`
+}
+
+type FunnelDatabricksJSON struct {
+	Destination FunnelDatabricksDestinationJSON `json:"destination"`
+	common.ExportSharedJSON
+}
+
+// databricksIdentifierPattern is the Unity Catalog naming rule for catalog, schema and table names. Unity Catalog stores
+// names in lowercase, so uppercase letters are rejected instead of showing a difference on every read.
+var databricksIdentifierPattern = regexp.MustCompile(`^[^. /\p{Cc}\p{Lu}]{1,255}$`)
+
+// databricksIdentifierValidators validate a catalog, schema or table name at plan time.
+func databricksIdentifierValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.RegexMatches(
+			databricksIdentifierPattern,
+			"must be lowercase, at most 255 characters and have no periods, spaces, slashes or control characters",
+		),
+	}
+}
+
+// databricksDestinationAttribute is the Databricks destination, shared with the databricks block of funnel_export.
+func databricksDestinationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Databricks Unity Catalog destination table",
+		Required:    true,
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				Description: "Host name of the Databricks workspace without a scheme, e.g. adb-1234567890123456.7.azuredatabricks.net",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)+$`),
+						"must be a host name without a scheme or path, e.g. adb-1234567890123456.7.azuredatabricks.net",
+					),
+				},
+			},
+			"catalog": schema.StringAttribute{
+				Description: "Unity Catalog catalog to export data to",
+				Required:    true,
+				Validators:  databricksIdentifierValidators(),
+			},
+			"schema_name": schema.StringAttribute{
+				Description: "Schema name to export data to",
+				Required:    true,
+				Validators:  databricksIdentifierValidators(),
+			},
+			"table_name": schema.StringAttribute{
+				Description: "Table name to export data to",
+				Required:    true,
+				Validators:  databricksIdentifierValidators(),
+			},
+			"warehouse_id": schema.StringAttribute{
+				Description: "ID of the SQL warehouse that writes the data",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[0-9a-f]{16}$`),
+						"must be a SQL warehouse ID of 16 hexadecimal characters",
+					),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				Description: "Databricks personal access token or service principal token",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"access_token_wo": schema.StringAttribute{
+				Description: "Write-only Databricks access token that is never stored in the plan or state. Requires Terraform 1.11 or later and credentials_wo_version.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("access_token")),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("credentials_wo_version")),
+				},
+			},
+			"credentials_wo_version": schema.Int64Attribute{
+				Description: "Version of the write-only credentials. Terraform can't detect changes of write-only values, so bump the version to send rotated credentials to Funnel.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// databricksDestinationValidators returns the rules of the Databricks destination at the path.
+func databricksDestinationValidators(destination path.Path) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		common.ExactlyOneOfAttributes(destination, "access_token", "access_token_wo"),
+		resourcevalidator.PreferWriteOnlyAttribute(
+			destination.Expression().AtName("access_token"),
+			destination.Expression().AtName("access_token_wo"),
+		),
+	}
+}
+
+func getDatabricksExport(ctx context.Context, client *funnel.Client, accountId string, id string) (*DatabricksResourceModel, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelDatabricksJSON](ctx, "exports", client, accountId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate that the export is a Databricks export
+	if respObj.Destination.Type != "databricks" {
+		return nil, fmt.Errorf("export %s is not a Databricks export (type: %s)", id, respObj.Destination.Type)
+	}
+
+	respObj.Fields = respObj.Query.Fields
+	respObj.Range = respObj.Query.Range
+	if respObj.Format.Type == "raw" {
+		respObj.Format.Type = "parquet"
+	}
+	// If not provided, the Exports API sets currency to "*" to pick up the workspace default currency
+	if respObj.Currency == "*" {
+		respObj.Currency = ""
+	}
+
+	respObj.Filters = common.ConvertFiltersFromMeld(respObj.Query.Where)
+
+	export, err := common.ConvertJSONToTF[FunnelDatabricksJSON, DatabricksResourceModel](respObj)
+	if err != nil {
+		return nil, err
+	}
+	export.SetFiltersFromMeld(respObj.Query.Where)
+
+	if respObj.Destination.AccessToken == "" {
+		export.Destination.AccessToken = types.StringNull()
+	}
+
+	return &export, nil
+}
+
+// databricksWriteOnlyCredentialNames are the credentials of the Databricks destination that can be write-only.
+var databricksWriteOnlyCredentialNames = []string{"access_token"}
+
+// withDatabricksWriteOnlyCredentials returns a copy of the model to send to the API with the write-only access token in place of the stored one.
+func withDatabricksWriteOnlyCredentials(model DatabricksResourceModel, credentials writeOnlyCredentials) DatabricksResourceModel {
+	model.Destination.AccessToken = credentials.replace("access_token", model.Destination.AccessToken)
+	return model
+}
+
+// keepDatabricksWriteOnlyState keeps the prior access token of a Databricks destination with a write-only access token.
+func keepDatabricksWriteOnlyState(destination *ExportDatabricksDestination, prior ExportDatabricksDestination) {
+	if keepWriteOnlyVersion(&destination.CredentialsWOVersion, prior.CredentialsWOVersion) {
+		destination.AccessToken = prior.AccessToken
+	}
+}
+
+// Mutating the Databricks export data before sending to the API with defaults and conversions.
+func prepareDatabricksExportData(ctx context.Context, data *FunnelDatabricksJSON, model DatabricksResourceModel) error {
+	mapped_filters, err := common.ConvertWhereToMeld(data.Filters, model.ExportShared)
+	if err != nil {
+		return err
+	}
+
+	data.OnlyAllowEditFromAPI = true
+	data.Type = "databricks"
+	data.Destination.Type = "databricks"
+	data.Query = common.QueryJSON{
+		Fields: data.Fields,
+		Range:  data.Range,
+		Where:  mapped_filters,
+	}
+	data.Format.Headers = "safename"
+	if data.Format.Type == "parquet" {
+		data.Format.Type = "raw"
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-funnel/provider/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newDatabricksTestModel() DatabricksResourceModel {
+	return DatabricksResourceModel{
+		ExportShared: common.ExportShared{
+			Name:      types.StringValue("test-export"),
+			Workspace: types.StringValue("test-workspace"),
+			Schedule:  types.StringValue("0 6 * * *"),
+			Format: common.ExportFormat{
+				Type:    types.StringValue("csv"),
+				Metrics: types.StringValue("export"),
+			},
+			Range:    common.ExportRange{Start: types.StringValue("2024-01-01")},
+			Timeouts: common.NullTimeouts(),
+		},
+		Destination: ExportDatabricksDestination{
+			Host:                 types.StringValue("adb-1234567890123456.7.azuredatabricks.net"),
+			Catalog:              types.StringValue("main"),
+			SchemaName:           types.StringValue("marketing"),
+			TableName:            types.StringValue("funnel_export"),
+			WarehouseId:          types.StringValue("abcdef0123456789"),
+			AccessTokenWO:        types.StringValue("wo-token"),
+			CredentialsWOVersion: types.Int64Value(1),
+		},
+	}
+}
+
+func TestDatabricksExport_SendsWriteOnlyCredentials(t *testing.T) {
+	var sent FunnelDatabricksJSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123"}`))
+	})
+
+	data := newDatabricksTestModel()
+	credentials := writeOnlyCredentials{"access_token": types.StringValue("wo-token")}
+
+	if _, err := databricksExportDestination.createModel(context.Background(), client, withDatabricksWriteOnlyCredentials(data, credentials)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if sent.Type != "databricks" || sent.Destination.Type != "databricks" {
+		t.Errorf("Expected export and destination type databricks, got %q and %q", sent.Type, sent.Destination.Type)
+	}
+	if sent.Destination.AccessToken != "wo-token" {
+		t.Errorf("Expected the write-only access token to be sent, got %q", sent.Destination.AccessToken)
+	}
+	if sent.Destination.WarehouseId != "abcdef0123456789" || sent.Destination.Catalog != "main" {
+		t.Errorf("Expected the warehouse and catalog to be sent, got %q and %q", sent.Destination.WarehouseId, sent.Destination.Catalog)
+	}
+	if !data.Destination.AccessToken.IsNull() {
+		t.Error("Expected the model saved to state to have no access token")
+	}
+}
+
+func TestDatabricksExport_Get(t *testing.T) {
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "export-123",
+			"name": "test-export",
+			"format": {"type": "csv", "metrics": "export"},
+			"destination": {"type": "databricks", "host": "adb-1234567890123456.7.azuredatabricks.net", "catalog": "main", "schemaName": "marketing", "tableName": "funnel_export", "warehouseId": "abcdef0123456789", "accessToken": "token"}
+		}`))
+	})
+
+	export, err := getDatabricksExport(context.Background(), client, "test-workspace", "export-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if export.Destination.TableName.ValueString() != "funnel_export" || export.Destination.WarehouseId.ValueString() != "abcdef0123456789" {
+		t.Errorf("Expected the table and warehouse from the API, got %v", export.Destination)
+	}
+	if export.Destination.AccessToken.ValueString() != "token" {
+		t.Errorf("Expected the access token from the API, got %v", export.Destination.AccessToken)
+	}
+
+	// The write-only access token stays out of state
+	keepDatabricksWriteOnlyState(&export.Destination, newDatabricksTestModel().Destination)
+	if !export.Destination.AccessToken.IsNull() || export.Destination.CredentialsWOVersion.IsNull() {
+		t.Errorf("Expected the write-only credentials state to be kept, got %+v", export.Destination)
+	}
+}
+
+func TestDatabricksResource_ConfigValidators_Credentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*ExportDatabricksDestination)
+		wantError bool
+	}{
+		{name: "write-only access token", configure: func(d *ExportDatabricksDestination) {}},
+		{
+			name: "access token",
+			configure: func(d *ExportDatabricksDestination) {
+				d.AccessTokenWO = types.StringNull()
+				d.CredentialsWOVersion = types.Int64Null()
+				d.AccessToken = types.StringValue("token")
+			},
+		},
+		{name: "no access token", configure: func(d *ExportDatabricksDestination) { d.AccessTokenWO = types.StringNull() }, wantError: true},
+		{name: "both access tokens", configure: func(d *ExportDatabricksDestination) { d.AccessToken = types.StringValue("token") }, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newDatabricksTestModel()
+			tt.configure(&data.Destination)
+
+			diags := validateResourceConfig(t, newExportDestinationResource(databricksExportDestination), data)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestDatabricksIdentifierValidators(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantError bool
+	}{
+		{value: "marketing"},
+		{value: "_funnel_2024"},
+		{value: "my-catalog"},
+		{value: "2024_marketing"},
+		{value: "marknadsföring"},
+		{value: strings.Repeat("t", 255)},
+		{value: strings.Repeat("ö", 255)},
+		{value: "Funnel_Export", wantError: true},
+		{value: "marketing.funnel", wantError: true},
+		{value: "funnel export", wantError: true},
+		{value: "funnel/export", wantError: true},
+		{value: "funnel\texport", wantError: true},
+		{value: "", wantError: true},
+		{value: strings.Repeat("t", 256), wantError: true},
+	}
+
+	for _, tt := range tests {
+		resp := &validator.StringResponse{}
+		for _, v := range databricksIdentifierValidators() {
+			v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("table_name"), ConfigValue: types.StringValue(tt.value)}, resp)
+		}
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("%q: expected error %v, got %v", tt.value, tt.wantError, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,481 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ExportResource{}
+var _ resource.ResourceWithImportState = &ExportResource{}
+var _ resource.ResourceWithConfigValidators = &ExportResource{}
+var _ resource.ResourceWithModifyPlan = &ExportResource{}
+var _ resource.ResourceWithMoveState = &ExportResource{}
+
+func NewExportResource() resource.Resource {
+	return &ExportResource{}
+}
+
+// ExportResource is an export to any destination in exportDestinations, configured with the block of the destination.
+type ExportResource struct {
+	client *funnel.Client
+}
+
+// FunnelExportDestinationTypeJSON is the part of an export that tells its destination.
+type FunnelExportDestinationTypeJSON struct {
+	Destination struct {
+		Type string `json:"type"`
+	} `json:"destination"`
+}
+
+func (r *ExportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_export"
+}
+
+func (r *ExportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	blocks := make([]string, len(exportDestinations))
+	for i, d := range exportDestinations {
+		blocks[i] = "`" + d.block() + "`"
+	}
+
+	resp.Schema = common.GetExportSharedSchema(ctx, "Export to the destination configured with exactly one of the "+strings.Join(blocks, ", ")+" blocks.")
+	for _, d := range exportDestinations {
+		attribute := d.attribute()
+		resp.Schema.Blocks[d.block()] = schema.SingleNestedBlock{
+			Description:         attribute.Description,
+			MarkdownDescription: attribute.MarkdownDescription,
+			Attributes:          attribute.Attributes,
+		}
+	}
+}
+
+func (r *ExportResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	validators := common.ExportConfigValidators()
+
+	blocks := make([]path.Expression, len(exportDestinations))
+	for i, d := range exportDestinations {
+		blocks[i] = path.MatchRoot(d.block())
+		validators = append(validators, d.configValidators(path.Root(d.block()))...)
+	}
+
+	return append(validators, resourcevalidator.ExactlyOneOf(blocks...))
+}
+
+func (r *ExportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*funnel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ExportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(verifyExportFields(ctx, r.client, &resp.Plan)...)
+
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Funnel can't change the destination type of an export, so a new destination block replaces it
+	for _, d := range exportDestinations {
+		var prior, planned types.Object
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(d.block()), &prior)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(d.block()), &planned)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if prior.IsNull() != planned.IsNull() {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root(d.block()))
+		}
+	}
+}
+
+func (r *ExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan types.Object
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, destination, destinationValue, diags := splitExportObject(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, data.Workspace.ValueString(), data.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create the export via API
+	data, destinationValue, err := destination.create(ctx, r.client, req.Config, data, destinationValue)
+	if err != nil {
+		var apiErr *funnel.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			resp.Diagnostics.AddError(
+				"Export in the same workspace with same destination configuration already exists",
+				fmt.Sprintf("An export with the same configuration already exists: %v", apiErr.Details),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Creating Export",
+			"Could not create export: "+err.Error(),
+		)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(setExportObject(ctx, &resp.State, data, destination, destinationValue)...)
+}
+
+func (r *ExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state types.Object
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, destination, priorDestination, diags := splitExportObject(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	export, destinationValue, err := destination.read(ctx, r.client, data.Workspace.ValueString(), data.Id.ValueString(), priorDestination)
+	if err != nil {
+		// If export not found, remove from state
+		var apiErr funnel.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Export",
+			"Could not read export ID "+data.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Merge API response with state - preserve ID and workspace, prefer API values for everything else
+	export.Id = data.Id
+	export.Workspace = data.Workspace
+	export.Timeouts = data.Timeouts
+	export.KeepScheduleConfig(data)
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(data)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(setExportObject(ctx, &resp.State, export, destination, destinationValue)...)
+}
+
+func (r *ExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan types.Object
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, destination, destinationValue, diags := splitExportObject(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, data.Workspace.ValueString(), data.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, destinationValue, err := destination.update(ctx, r.client, req.Config, data, destinationValue)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Export",
+			"Could not update export ID "+data.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(setExportObject(ctx, &resp.State, data, destination, destinationValue)...)
+}
+
+func (r *ExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data common.ExportShared
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("workspace"), &data.Workspace)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &data.Id)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("timeouts"), &data.Timeouts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Delete the export via API
+	err := funnel.DeleteWorkspaceEntity(ctx, "exports", r.client, data.Workspace.ValueString(), data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Export",
+			"Could not delete export ID "+data.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ExportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The import ID format should be "workspace_id/export_id".
+	idParts := strings.Split(req.ID, "/")
+	if len(idParts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected import ID in format 'workspace_id/export_id', got: "+req.ID,
+		)
+		return
+	}
+
+	workspaceID := idParts[0]
+	exportID := idParts[1]
+
+	// The destination type of the export picks the destination block
+	exportType, err := funnel.GetWorkspaceEntity[FunnelExportDestinationTypeJSON](ctx, "exports", r.client, workspaceID, exportID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Export",
+			"Could not read export ID "+exportID+" from workspace "+workspaceID+": "+err.Error(),
+		)
+		return
+	}
+
+	destination, ok := exportDestinationForType(exportType.Destination.Type)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unsupported Export Destination",
+			fmt.Sprintf("Export %s has destination type %q, which funnel_export doesn't support.", exportID, exportType.Destination.Type),
+		)
+		return
+	}
+
+	export, destinationValue, err := destination.read(ctx, r.client, workspaceID, exportID, types.ObjectNull(destinationAttributeTypes(destination)))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Export",
+			"Could not read export ID "+exportID+" from workspace "+workspaceID+": "+err.Error(),
+		)
+		return
+	}
+
+	export.Id = types.StringValue(exportID)
+	export.Workspace = types.StringValue(workspaceID)
+	export.Timeouts = common.NullTimeouts()
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(common.ExportShared{})...)
+
+	resp.Diagnostics.Append(setExportObject(ctx, &resp.State, export, destination, destinationValue)...)
+}
+
+func (r *ExportResource) MoveState(ctx context.Context) []resource.StateMover {
+	movers := make([]resource.StateMover, 0, len(exportDestinations))
+	for _, d := range exportDestinations {
+		legacy := d.legacyResource()
+
+		schemaResp := &resource.SchemaResponse{}
+		legacy.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+		// Without a provider type name the type name is only the suffix, e.g. _gcs_export
+		metadataResp := &resource.MetadataResponse{}
+		legacy.Metadata(ctx, resource.MetadataRequest{}, metadataResp)
+
+		movers = append(movers, resource.StateMover{
+			SourceSchema: &schemaResp.Schema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !strings.HasSuffix(req.SourceTypeName, metadataResp.TypeName) || !strings.HasSuffix(req.SourceProviderAddress, "/funnel") {
+					return
+				}
+				moveExportState(ctx, d, req, resp)
+			},
+		})
+	}
+	return movers
+}
+
+// moveExportState moves the state of a per-destination export resource, where the destination attribute becomes the destination block.
+func moveExportState(ctx context.Context, destination exportDestination, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceState == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Export State",
+			fmt.Sprintf("The state of %s doesn't match its schema. Please upgrade it with the current provider version before moving it.", req.SourceTypeName),
+		)
+		return
+	}
+
+	var source types.Object
+	resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributes := source.Attributes()
+	destinationValue, ok := attributes["destination"].(types.Object)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to Move Export State",
+			fmt.Sprintf("The state of %s has no destination.", req.SourceTypeName),
+		)
+		return
+	}
+	delete(attributes, "destination")
+
+	var data common.ExportShared
+	resp.Diagnostics.Append(exportSharedValue(ctx, attributes).As(ctx, &data, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setExportObject(ctx, &resp.TargetState, data, destination, destinationValue)...)
+}
+
+// splitExportObject splits a funnel_export value into the shared export attributes and the configured destination block.
+func splitExportObject(ctx context.Context, object types.Object) (common.ExportShared, exportDestination, types.Object, diag.Diagnostics) {
+	var data common.ExportShared
+	var diags diag.Diagnostics
+
+	var destination exportDestination
+	var destinationValue types.Object
+	shared := map[string]attr.Value{}
+	for name, value := range object.Attributes() {
+		if d, ok := exportDestinationForBlock(name); ok {
+			if !value.IsNull() {
+				destination, destinationValue = d, value.(types.Object)
+			}
+			continue
+		}
+		shared[name] = value
+	}
+
+	if destination == nil {
+		diags.AddError(
+			"Missing Export Destination",
+			"The export has no destination block. Please report this issue to the provider developers.",
+		)
+		return data, nil, destinationValue, diags
+	}
+
+	diags.Append(exportSharedValue(ctx, shared).As(ctx, &data, basetypes.ObjectAsOptions{})...)
+	return data, destination, destinationValue, diags
+}
+
+// exportSharedValue is the object of the shared export attributes.
+func exportSharedValue(ctx context.Context, attributes map[string]attr.Value) types.Object {
+	attributeTypes := make(map[string]attr.Type, len(attributes))
+	for name, value := range attributes {
+		attributeTypes[name] = value.Type(ctx)
+	}
+	return types.ObjectValueMust(attributeTypes, attributes)
+}
+
+// setExportObject sets the shared export attributes and the destination block as the funnel_export value, with the other destination blocks null.
+func setExportObject(
+	ctx context.Context,
+	state *tfsdk.State,
+	data common.ExportShared,
+	destination exportDestination,
+	destinationValue types.Object,
+) diag.Diagnostics {
+	attributeTypes := state.Schema.Type().(types.ObjectType).AttrTypes
+
+	sharedTypes := map[string]attr.Type{}
+	for name, attributeType := range attributeTypes {
+		if _, ok := exportDestinationForBlock(name); !ok {
+			sharedTypes[name] = attributeType
+		}
+	}
+
+	shared, diags := types.ObjectValueFrom(ctx, sharedTypes, data)
+	if diags.HasError() {
+		return diags
+	}
+
+	attributes := shared.Attributes()
+	for _, d := range exportDestinations {
+		attributes[d.block()] = types.ObjectNull(destinationAttributeTypes(d))
+	}
+	attributes[destination.block()] = destinationValue
+
+	object, objectDiags := types.ObjectValue(attributeTypes, attributes)
+	diags.Append(objectDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(state.Set(ctx, object)...)
+	return diags
+}
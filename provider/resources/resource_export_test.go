@@ -0,0 +1,228 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-funnel/provider/auth"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newExportTestState returns an empty funnel_export state to set.
+func newExportTestState(t *testing.T) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&ExportResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	return tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+}
+
+func TestExportResource_Metadata(t *testing.T) {
+	r := ExportResource{}
+
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "funnel"}, resp)
+	if resp.TypeName != "funnel_export" {
+		t.Errorf("Expected TypeName to be funnel_export, got %s", resp.TypeName)
+	}
+}
+
+func TestExportDestinations_LegacyResourceMetadata(t *testing.T) {
+	for _, d := range exportDestinations {
+		t.Run(d.block(), func(t *testing.T) {
+			resp := &resource.MetadataResponse{}
+			d.legacyResource().Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "funnel"}, resp)
+			if want := "funnel_" + d.block() + "_export"; resp.TypeName != want {
+				t.Errorf("Expected TypeName to be %s, got %s", want, resp.TypeName)
+			}
+		})
+	}
+}
+
+func TestExportDestinations_Read_OtherDestination(t *testing.T) {
+	for i, d := range exportDestinations {
+		other := exportDestinations[(i+1)%len(exportDestinations)]
+
+		t.Run(d.block(), func(t *testing.T) {
+			client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"id":"export-123","destination":{"type":%q}}`, other.destinationType())
+			})
+
+			prior := types.ObjectNull(destinationAttributeTypes(d))
+			if _, _, err := d.read(context.Background(), client, "test-workspace", "export-123", prior); err == nil {
+				t.Errorf("Expected an error for a %s export", other.block())
+			}
+		})
+	}
+}
+
+func TestExportDestinations_Read_WhereJSON(t *testing.T) {
+	where := `{"=or":[{"=and":[{"=or":[{"sourceType":{"=eq":"adwords"}},{"cost":{"=gt":100}}]}]}]}`
+
+	for _, d := range exportDestinations {
+		t.Run(d.block(), func(t *testing.T) {
+			client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"id":"export-123","destination":{"type":%q},"query":{"where":%s}}`, d.destinationType(), where)
+			})
+
+			prior := types.ObjectNull(destinationAttributeTypes(d))
+			shared, _, err := d.read(context.Background(), client, "test-workspace", "export-123", prior)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if shared.Filter != nil || shared.WhereJSON.ValueString() != where {
+				t.Errorf("Expected the filter read into where_json, got %+v and %v", shared.Filter, shared.WhereJSON)
+			}
+		})
+	}
+}
+
+func TestExportResource_ImportState(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  string
+		wantBlock string
+		wantError string
+	}{
+		{
+			name:      "gcs",
+			response:  `{"id":"export-123","name":"test-export","type":"gcs","schedule":"0 6 * * *","format":{"type":"csv","metrics":"export"},"destination":{"type":"gcs","bucket":"test-bucket","path":"exports"}}`,
+			wantBlock: "gcs",
+		},
+		{
+			name:      "bigquery",
+			response:  `{"id":"export-123","name":"test-export","type":"bigquery","schedule":"0 6 * * *","format":{"type":"raw","metrics":"export"},"destination":{"type":"bigquery","projectId":"test-project","datasetId":"test_dataset"}}`,
+			wantBlock: "bigquery",
+		},
+		{
+			name:      "unsupported destination",
+			response:  `{"id":"export-123","name":"test-export","type":"ftp","destination":{"type":"ftp"}}`,
+			wantError: "Unsupported Export Destination",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response))
+			}))
+			defer mockServer.Close()
+
+			r := ExportResource{client: funnel.NewClient(funnel.ClientConfig{
+				Environment: mockServer.URL + "/v1",
+				TokenSource: auth.StaticTokenSource("test-token"),
+			})}
+
+			ctx := context.Background()
+			resp := &resource.ImportStateResponse{State: newExportTestState(t)}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: "test-workspace/export-123"}, resp)
+
+			if tt.wantError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.wantError {
+					t.Fatalf("Expected a %q error, got %v", tt.wantError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+			}
+
+			for _, d := range exportDestinations {
+				var block types.Object
+				resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root(d.block()), &block)...)
+				if block.IsNull() != (d.block() != tt.wantBlock) {
+					t.Errorf("Expected only the %s block to be set, got %s = %v", tt.wantBlock, d.block(), block)
+				}
+			}
+
+			var workspace types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("workspace"), &workspace)...)
+			if workspace.ValueString() != "test-workspace" {
+				t.Errorf("Expected workspace test-workspace, got %v", workspace)
+			}
+		})
+	}
+}
+
+func TestExportResource_MoveState(t *testing.T) {
+	ctx := context.Background()
+
+	source := FunnelGCSResource{
+		ExportShared: common.ExportShared{
+			Id:        types.StringValue("export-123"),
+			Name:      types.StringValue("test-export"),
+			Workspace: types.StringValue("test-workspace"),
+			Schedule:  types.StringValue("0 6 * * *"),
+			Format: common.ExportFormat{
+				Type:    types.StringValue("csv"),
+				Metrics: types.StringValue("export"),
+			},
+			Timeouts: common.NullTimeouts(),
+		},
+		Destination: FunnelGCSDestination{
+			Bucket:      types.StringValue("test-bucket"),
+			Path:        types.StringValue("exports"),
+			GZip:        types.BoolValue(true),
+			SchemaFile:  types.ObjectNull(gcsSideFileAttributeTypes),
+			SummaryFile: types.ObjectNull(gcsSideFileAttributeTypes),
+		},
+	}
+
+	var mover *resource.StateMover
+	movers := (&ExportResource{}).MoveState(ctx)
+	for i, d := range exportDestinations {
+		if d.block() == "gcs" {
+			mover = &movers[i]
+		}
+	}
+
+	sourceState := tfsdk.State{Schema: *mover.SourceSchema, Raw: tftypes.NewValue(mover.SourceSchema.Type().TerraformType(ctx), nil)}
+	if diags := sourceState.Set(ctx, source); diags.HasError() {
+		t.Fatalf("could not build the source state: %v", diags)
+	}
+
+	req := resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/funnel-io/funnel",
+		SourceTypeName:        "funnel_gcs_export",
+		SourceState:           &sourceState,
+	}
+	resp := &resource.MoveStateResponse{TargetState: newExportTestState(t)}
+	mover.StateMover(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+	}
+
+	var bucket types.String
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("gcs").AtName("bucket"), &bucket)...)
+	if bucket.ValueString() != "test-bucket" {
+		t.Errorf("Expected gcs.bucket test-bucket, got %v", bucket)
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("id"), &id)...)
+	if id.ValueString() != "export-123" {
+		t.Errorf("Expected id export-123, got %v", id)
+	}
+
+	// Another resource type isn't moved by the GCS mover
+	req.SourceTypeName = "funnel_bigquery_export"
+	resp = &resource.MoveStateResponse{TargetState: newExportTestState(t)}
+	mover.StateMover(ctx, req, resp)
+	if !resp.TargetState.Raw.IsNull() || resp.Diagnostics.HasError() {
+		t.Errorf("Expected funnel_bigquery_export not to be moved by the gcs mover")
+	}
+}
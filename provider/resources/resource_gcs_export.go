@@ -7,21 +7,31 @@ import (
 	"terraform-provider-funnel/provider/common"
 	"terraform-provider-funnel/provider/funnel"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GCSResource{}
 var _ resource.ResourceWithImportState = &GCSResource{}
+var _ resource.ResourceWithConfigValidators = &GCSResource{}
+var _ resource.ResourceWithModifyPlan = &GCSResource{}
 
 func NewGCSResource() resource.Resource {
 	return &GCSResource{}
 }
 
 type GCSResource struct {
-	config *common.FunnelProviderModel
+	client *funnel.Client
 }
 
 type FunnelGCSDestination struct {
@@ -30,6 +40,23 @@ type FunnelGCSDestination struct {
 	Bucket           types.String `tfsdk:"bucket"`
 	GZip             types.Bool   `tfsdk:"gzip"`
 	CredentialsRef   types.String `tfsdk:"credentials_ref"`
+	Headers          types.String `tfsdk:"headers"`
+	// Objects since they are computed as a whole when not configured.
+	SchemaFile  types.Object `tfsdk:"schema_file"`
+	SummaryFile types.Object `tfsdk:"summary_file"`
+}
+
+// GCSSideFile is a schema or summary file that Funnel writes next to the exported files.
+type GCSSideFile struct {
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	Format     types.String `tfsdk:"format"`
+	IdTemplate types.String `tfsdk:"id_template"`
+}
+
+var gcsSideFileAttributeTypes = map[string]attr.Type{
+	"enabled":     types.BoolType,
+	"format":      types.StringType,
+	"id_template": types.StringType,
 }
 
 type FunnelGCSResource struct {
@@ -44,7 +71,7 @@ type FunnelGCSDestinationJSON struct {
 	Bucket           string `json:"bucket"`
 	GZip             bool   `json:"gzip"`
 	CredentialsRef   string `json:"credentialsRef"`
-	// Can't be configured via Terraform currently.
+	// Empty when the side file is disabled.
 	SummaryFileFormat     string `json:"summaryFileFormat"`
 	SummaryFileIdTemplate string `json:"summaryFileIdTemplate"`
 	SchemaFileFormat      string `json:"schemaFileFormat"`
@@ -61,7 +88,12 @@ func (r *GCSResource) Metadata(ctx context.Context, req resource.MetadataRequest
 }
 
 func (r *GCSResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = common.GetExportSchema(schema.SingleNestedAttribute{
+	resp.Schema = common.GetExportSchema(ctx, gcsDestinationAttribute(), "GCS export")
+}
+
+// gcsDestinationAttribute is the GCS destination, shared with the gcs block of funnel_export.
+func gcsDestinationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
 		MarkdownDescription: "GCS destination",
 		Required:            true,
 		Attributes: map[string]schema.Attribute{
@@ -78,16 +110,153 @@ func (r *GCSResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Required:            true,
 			},
 			"gzip": schema.BoolAttribute{
-				MarkdownDescription: "Whether to gzip the exported files",
+				MarkdownDescription: "Whether to gzip the exported files. Defaults to `true`",
 				Optional:            true,
 				Computed:            true,
+				Default:             booldefault.StaticBool(true),
 			},
 			"credentials_ref": schema.StringAttribute{
 				MarkdownDescription: "Reference to GCS credentials secret",
 				Optional:            true,
 			},
+			"headers": schema.StringAttribute{
+				MarkdownDescription: "Style of the column headers of the exported files, one of `safename`, `name` or `id`. Defaults to `safename`, the field names made safe for databases",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(gcsDefaultHeaders),
+				Validators: []validator.String{
+					stringvalidator.OneOf(gcsDefaultHeaders, "name", "id"),
+				},
+			},
+			"schema_file": gcsSideFileAttribute(
+				"Schema file describing the columns of the exported files",
+				gcsSchemaFileFormat,
+				gcsSchemaFileIdTemplate,
+			),
+			"summary_file": gcsSideFileAttribute(
+				"Summary file of each export run",
+				gcsSummaryFileFormat,
+				gcsSummaryFileIdTemplate,
+			),
+		},
+	}
+}
+
+// Defaults of the headers and side files, as the provider has always sent them.
+const (
+	gcsDefaultHeaders = "safename"
+
+	gcsSchemaFileFormat      = "sql"
+	gcsSchemaFileIdTemplate  = "{runId}/funnel_schema"
+	gcsSummaryFileFormat     = "csv"
+	gcsSummaryFileIdTemplate = "{runId}/funnel_summary"
+)
+
+// gcsSideFileAttribute is a schema or summary file that can be moved with its ID template or disabled.
+func gcsSideFileAttribute(description string, defaultFormat string, defaultIdTemplate string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description + ". Written by default",
+		Optional:            true,
+		Computed:            true,
+		Default:             objectdefault.StaticValue(gcsSideFileValue(defaultFormat, defaultIdTemplate)),
+		Validators: []validator.Object{
+			gcsSideFileValidator{},
 		},
-	}, "GCS export")
+		Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether to write the file. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Format of the file. Defaults to `%s`", defaultFormat),
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					gcsSideFileDefault(defaultFormat),
+				},
+			},
+			"id_template": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("ID template of the file relative to `path`. Defaults to `%s`", defaultIdTemplate),
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					gcsSideFileDefault(defaultIdTemplate),
+				},
+			},
+		},
+	}
+}
+
+type gcsSideFileDefaultModifier struct {
+	value string
+}
+
+// gcsSideFileDefault plans the default of a side file attribute that isn't configured, or null when the side file is
+// disabled since Funnel doesn't keep it then.
+func gcsSideFileDefault(value string) planmodifier.String {
+	return gcsSideFileDefaultModifier{value: value}
+}
+
+func (m gcsSideFileDefaultModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Defaults to %s unless the file is disabled", m.value)
+}
+
+func (m gcsSideFileDefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Defaults to `%s` unless the file is disabled", m.value)
+}
+
+func (m gcsSideFileDefaultModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var enabled types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("enabled"), &enabled)...)
+	if resp.Diagnostics.HasError() || enabled.IsUnknown() {
+		return
+	}
+
+	if !enabled.IsNull() && !enabled.ValueBool() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+	resp.PlanValue = types.StringValue(m.value)
+}
+
+// gcsSideFileValidator rejects a format or ID template of a disabled side file, since Funnel doesn't keep them.
+type gcsSideFileValidator struct{}
+
+func (v gcsSideFileValidator) Description(ctx context.Context) string {
+	return "format and id_template can't be set when enabled is false"
+}
+
+func (v gcsSideFileValidator) MarkdownDescription(ctx context.Context) string {
+	return "`format` and `id_template` can't be set when `enabled` is `false`"
+}
+
+func (v gcsSideFileValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var file GCSSideFile
+	resp.Diagnostics.Append(req.ConfigValue.As(ctx, &file, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() || file.Enabled.IsNull() || file.Enabled.IsUnknown() || file.Enabled.ValueBool() {
+		return
+	}
+
+	if !file.Format.IsNull() {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("format"), "Invalid Attribute Combination", v.Description(ctx)+".")
+	}
+	if !file.IdTemplate.IsNull() {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("id_template"), "Invalid Attribute Combination", v.Description(ctx)+".")
+	}
+}
+
+func (r *GCSResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return common.ExportConfigValidators()
 }
 
 func (r *GCSResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -96,18 +265,22 @@ func (r *GCSResource) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 
-	config, ok := req.ProviderData.(*common.FunnelProviderModel)
+	client, ok := req.ProviderData.(*funnel.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *FunnelProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.config = config
+	r.client = client
+}
+
+func (r *GCSResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(verifyExportFields(ctx, r.client, &resp.Plan)...)
 }
 
 func (r *GCSResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -119,13 +292,27 @@ func (r *GCSResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, data.Workspace.ValueString(), data.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	respObj, err := createExport(
 		ctx,
-		r.config,
+		r.client,
 		data,
 	)
 	if err != nil {
@@ -136,9 +323,9 @@ func (r *GCSResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	// Set the ID from the API response
+	// Set the ID and the values defaulted by Funnel from the API response
 	data.Id = types.StringValue(respObj.Id)
-	data.Destination.GZip = types.BoolValue(respObj.Destination.GZip)
+	setGCSDestinationFromAPI(&data.Destination, respObj)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -154,9 +341,18 @@ func (r *GCSResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	export, err := getExport(
 		ctx,
-		r.config,
+		r.client,
 		data.Workspace.ValueString(),
 		data.Id.ValueString(),
 	)
@@ -177,6 +373,9 @@ func (r *GCSResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	// Merge API response with state - preserve ID and workspace, prefer API values for everything else
 	export.Id = data.Id
 	export.Workspace = data.Workspace
+	export.Timeouts = data.Timeouts
+	export.KeepScheduleConfig(data.ExportShared)
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(data.ExportShared)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &export)...)
@@ -191,13 +390,27 @@ func (r *GCSResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, data.Workspace.ValueString(), data.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	respObj, err := updateExport(
 		ctx,
-		r.config,
+		r.client,
 		data,
 	)
 	if err != nil {
@@ -208,7 +421,7 @@ func (r *GCSResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	data.Destination.GZip = types.BoolValue(respObj.Destination.GZip)
+	setGCSDestinationFromAPI(&data.Destination, respObj)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -223,9 +436,18 @@ func (r *GCSResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := deleteGCSExport(
 		ctx,
-		r.config,
+		r.client,
 		data.Workspace.ValueString(),
 		data.Id.ValueString(),
 	)
@@ -252,7 +474,7 @@ func (r *GCSResource) ImportState(ctx context.Context, req resource.ImportStateR
 	workspaceID := idParts[0]
 	exportID := idParts[1]
 
-	export, err := getExport(ctx, r.config, workspaceID, exportID)
+	export, err := getExport(ctx, r.client, workspaceID, exportID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Importing GCS Export",
@@ -263,12 +485,14 @@ func (r *GCSResource) ImportState(ctx context.Context, req resource.ImportStateR
 
 	export.Id = types.StringValue(exportID)
 	export.Workspace = types.StringValue(workspaceID)
+	export.Timeouts = common.NullTimeouts()
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(common.ExportShared{})...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, export)...)
 }
 
-func getExport(ctx context.Context, config *common.FunnelProviderModel, accountId string, id string) (*FunnelGCSResource, error) {
-	respObj, err := funnel.GetWorkspaceEntity[FunnelGCSJSON](ctx, "exports", config, accountId, id)
+func getExport(ctx context.Context, client *funnel.Client, accountId string, id string) (*FunnelGCSResource, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelGCSJSON](ctx, "exports", client, accountId, id)
 	if err != nil {
 		return nil, err
 	}
@@ -293,55 +517,130 @@ func getExport(ctx context.Context, config *common.FunnelProviderModel, accountI
 	if err != nil {
 		return nil, err
 	}
+	export.SetFiltersFromMeld(respObj.Query.Where)
+	setGCSDestinationFromAPI(&export.Destination, respObj)
 
 	return &export, nil
 }
 
-func createExport(ctx context.Context, config *common.FunnelProviderModel, model FunnelGCSResource) (FunnelGCSJSON, *funnel.APIError) {
+func createExport(ctx context.Context, client *funnel.Client, model FunnelGCSResource) (FunnelGCSJSON, *funnel.APIError) {
 	data, err := common.ConvertTFToJSON[FunnelGCSResource, FunnelGCSJSON](model)
 	if err != nil {
 		return FunnelGCSJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
 	}
 
-	prepareGCSExportData(&data, model)
+	if err := prepareGCSExportData(ctx, &data, model); err != nil {
+		return FunnelGCSJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
+	}
 
-	return funnel.CreateWorkspaceEntity[FunnelGCSJSON, FunnelGCSJSON](ctx, "exports", config, model.Workspace.ValueString(), data)
+	return funnel.CreateWorkspaceEntity[FunnelGCSJSON, FunnelGCSJSON](ctx, "exports", client, model.Workspace.ValueString(), data)
 }
 
-func updateExport(ctx context.Context, config *common.FunnelProviderModel, model FunnelGCSResource) (FunnelGCSJSON, error) {
+func updateExport(ctx context.Context, client *funnel.Client, model FunnelGCSResource) (FunnelGCSJSON, error) {
 	data, err := common.ConvertTFToJSON[FunnelGCSResource, FunnelGCSJSON](model)
 	if err != nil {
 		return FunnelGCSJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
 	}
 
-	prepareGCSExportData(&data, model)
+	if err := prepareGCSExportData(ctx, &data, model); err != nil {
+		return FunnelGCSJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
+	}
+
+	return funnel.UpdateWorkspaceEntity[FunnelGCSJSON, FunnelGCSJSON](ctx, "exports", client, model.Workspace.ValueString(), model.Id.ValueString(), data)
+}
+
+func deleteGCSExport(ctx context.Context, client *funnel.Client, accountId string, id string) error {
+	return funnel.DeleteWorkspaceEntity(ctx, "exports", client, accountId, id)
+}
 
-	return funnel.UpdateWorkspaceEntity[FunnelGCSJSON, FunnelGCSJSON](ctx, "exports", config, model.Workspace.ValueString(), model.Id.ValueString(), data)
+// setGCSDestinationFromAPI sets the destination attributes that Funnel defaults from the export returned by the API.
+func setGCSDestinationFromAPI(destination *FunnelGCSDestination, respObj FunnelGCSJSON) {
+	destination.GZip = types.BoolValue(respObj.Destination.GZip)
+	destination.Headers = types.StringValue(respObj.Format.Headers)
+	destination.SchemaFile = gcsSideFileValue(respObj.Destination.SchemaFileFormat, respObj.Destination.SchemaFileIdTemplate)
+	destination.SummaryFile = gcsSideFileValue(respObj.Destination.SummaryFileFormat, respObj.Destination.SummaryFileIdTemplate)
 }
 
-func deleteGCSExport(ctx context.Context, config *common.FunnelProviderModel, accountId string, id string) error {
-	return funnel.DeleteWorkspaceEntity(ctx, "exports", config, accountId, id)
+// gcsSideFileValue is the side file read from the API. A side file without a format is disabled.
+func gcsSideFileValue(format string, idTemplate string) types.Object {
+	if format == "" {
+		return types.ObjectValueMust(gcsSideFileAttributeTypes, map[string]attr.Value{
+			"enabled":     types.BoolValue(false),
+			"format":      types.StringNull(),
+			"id_template": types.StringNull(),
+		})
+	}
+
+	return types.ObjectValueMust(gcsSideFileAttributeTypes, map[string]attr.Value{
+		"enabled":     types.BoolValue(true),
+		"format":      types.StringValue(format),
+		"id_template": types.StringValue(idTemplate),
+	})
+}
+
+// gcsSideFileJSON returns the format and ID template of the side file to send to the API. Whatever isn't configured
+// gets the default, and a disabled side file has neither.
+func gcsSideFileJSON(ctx context.Context, file types.Object, defaultFormat string, defaultIdTemplate string) (string, string, error) {
+	if file.IsNull() || file.IsUnknown() {
+		return defaultFormat, defaultIdTemplate, nil
+	}
+
+	var sideFile GCSSideFile
+	if err := diagnosticsError(file.As(ctx, &sideFile, basetypes.ObjectAsOptions{})); err != nil {
+		return "", "", err
+	}
+	if !sideFile.Enabled.IsNull() && !sideFile.Enabled.IsUnknown() && !sideFile.Enabled.ValueBool() {
+		return "", "", nil
+	}
+
+	format, idTemplate := defaultFormat, defaultIdTemplate
+	if !sideFile.Format.IsNull() && !sideFile.Format.IsUnknown() {
+		format = sideFile.Format.ValueString()
+	}
+	if !sideFile.IdTemplate.IsNull() && !sideFile.IdTemplate.IsUnknown() {
+		idTemplate = sideFile.IdTemplate.ValueString()
+	}
+	return format, idTemplate, nil
 }
 
 // Mutating the GCS export data before sending to the API with defaults and conversions.
-func prepareGCSExportData(data *FunnelGCSJSON, model FunnelGCSResource) {
-	mapped_filters := common.ConvertFiltersToMeld(data.Filters)
+func prepareGCSExportData(ctx context.Context, data *FunnelGCSJSON, model FunnelGCSResource) error {
+	mapped_filters, err := common.ConvertWhereToMeld(data.Filters, model.ExportShared)
+	if err != nil {
+		return err
+	}
 
 	data.OnlyAllowEditFromAPI = true
 	data.Type = "gcs"
 	data.Destination.Type = "gcs"
-	data.Destination.GZip = data.Destination.GZip || true
-	data.Destination.SchemaFileFormat = "sql"
-	data.Destination.SchemaFileIdTemplate = "{runId}/funnel_schema"
-	data.Destination.SummaryFileFormat = "csv"
-	data.Destination.SummaryFileIdTemplate = "{runId}/funnel_summary"
+	// Files are gzipped unless gzip is set to false
+	data.Destination.GZip = model.Destination.GZip.IsNull() || model.Destination.GZip.IsUnknown() || model.Destination.GZip.ValueBool()
+
+	data.Destination.SchemaFileFormat, data.Destination.SchemaFileIdTemplate, err = gcsSideFileJSON(
+		ctx, model.Destination.SchemaFile, gcsSchemaFileFormat, gcsSchemaFileIdTemplate,
+	)
+	if err != nil {
+		return err
+	}
+	data.Destination.SummaryFileFormat, data.Destination.SummaryFileIdTemplate, err = gcsSideFileJSON(
+		ctx, model.Destination.SummaryFile, gcsSummaryFileFormat, gcsSummaryFileIdTemplate,
+	)
+	if err != nil {
+		return err
+	}
+
 	data.Query = common.QueryJSON{
 		Fields: data.Fields,
 		Range:  data.Range,
 		Where:  mapped_filters,
 	}
-	data.Format.Headers = "safename"
+	data.Format.Headers = gcsDefaultHeaders
+	if !model.Destination.Headers.IsNull() && !model.Destination.Headers.IsUnknown() {
+		data.Format.Headers = model.Destination.Headers.ValueString()
+	}
 	if data.Format.Type == "parquet" {
 		data.Format.Type = "raw"
 	}
+
+	return nil
 }
@@ -0,0 +1,207 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"terraform-provider-funnel/provider/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func newGCSTestModel() FunnelGCSResource {
+	return FunnelGCSResource{
+		ExportShared: common.ExportShared{
+			Name:      types.StringValue("test-export"),
+			Workspace: types.StringValue("test-workspace"),
+			Schedule:  types.StringValue("0 6 * * *"),
+			Format: common.ExportFormat{
+				Type:    types.StringValue("parquet"),
+				Metrics: types.StringValue("export"),
+			},
+			Range:    common.ExportRange{Start: types.StringValue("2024-01-01")},
+			Timeouts: common.NullTimeouts(),
+		},
+		Destination: FunnelGCSDestination{
+			OutputIdTemplate: types.StringValue("funnel_export_{date}"),
+			Path:             types.StringValue("exports"),
+			Bucket:           types.StringValue("test-bucket"),
+			GZip:             types.BoolUnknown(),
+			Headers:          types.StringUnknown(),
+			SchemaFile:       types.ObjectUnknown(gcsSideFileAttributeTypes),
+			SummaryFile:      types.ObjectUnknown(gcsSideFileAttributeTypes),
+		},
+	}
+}
+
+func newGCSSideFile(enabled bool, format attr.Value, idTemplate attr.Value) types.Object {
+	return types.ObjectValueMust(gcsSideFileAttributeTypes, map[string]attr.Value{
+		"enabled":     types.BoolValue(enabled),
+		"format":      format,
+		"id_template": idTemplate,
+	})
+}
+
+func TestGCSExport_Create_Defaults(t *testing.T) {
+	var sent FunnelGCSJSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123"}`))
+	})
+
+	if _, err := createExport(context.Background(), client, newGCSTestModel()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !sent.Destination.GZip || sent.Format.Headers != "safename" {
+		t.Errorf("Expected gzip and safename headers by default, got %v and %q", sent.Destination.GZip, sent.Format.Headers)
+	}
+	if sent.Destination.SchemaFileFormat != "sql" || sent.Destination.SchemaFileIdTemplate != "{runId}/funnel_schema" {
+		t.Errorf("Expected the default schema file, got %q and %q", sent.Destination.SchemaFileFormat, sent.Destination.SchemaFileIdTemplate)
+	}
+	if sent.Destination.SummaryFileFormat != "csv" || sent.Destination.SummaryFileIdTemplate != "{runId}/funnel_summary" {
+		t.Errorf("Expected the default summary file, got %q and %q", sent.Destination.SummaryFileFormat, sent.Destination.SummaryFileIdTemplate)
+	}
+}
+
+func TestGCSExport_Create_Options(t *testing.T) {
+	var sent FunnelGCSJSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123"}`))
+	})
+
+	data := newGCSTestModel()
+	data.Destination.GZip = types.BoolValue(false)
+	data.Destination.Headers = types.StringValue("name")
+	data.Destination.SchemaFile = newGCSSideFile(false, types.StringNull(), types.StringNull())
+	data.Destination.SummaryFile = newGCSSideFile(true, types.StringUnknown(), types.StringValue("summaries/{runId}"))
+
+	if _, err := createExport(context.Background(), client, data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if sent.Destination.GZip || sent.Format.Headers != "name" {
+		t.Errorf("Expected no gzip and name headers, got %v and %q", sent.Destination.GZip, sent.Format.Headers)
+	}
+	if sent.Destination.SchemaFileFormat != "" || sent.Destination.SchemaFileIdTemplate != "" {
+		t.Errorf("Expected the schema file to be disabled, got %q and %q", sent.Destination.SchemaFileFormat, sent.Destination.SchemaFileIdTemplate)
+	}
+	if sent.Destination.SummaryFileFormat != "csv" || sent.Destination.SummaryFileIdTemplate != "summaries/{runId}" {
+		t.Errorf("Expected the moved summary file, got %q and %q", sent.Destination.SummaryFileFormat, sent.Destination.SummaryFileIdTemplate)
+	}
+}
+
+func TestGCSExport_Get(t *testing.T) {
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "export-123",
+			"name": "test-export",
+			"format": {"type": "raw", "metrics": "export", "headers": "name"},
+			"destination": {"type": "gcs", "bucket": "test-bucket", "path": "exports", "gzip": false, "summaryFileFormat": "csv", "summaryFileIdTemplate": "summaries/{runId}"}
+		}`))
+	})
+
+	export, err := getExport(context.Background(), client, "test-workspace", "export-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if export.Destination.GZip.ValueBool() || export.Destination.Headers.ValueString() != "name" {
+		t.Errorf("Expected gzip and headers from the API, got %v and %v", export.Destination.GZip, export.Destination.Headers)
+	}
+	if want := newGCSSideFile(false, types.StringNull(), types.StringNull()); !export.Destination.SchemaFile.Equal(want) {
+		t.Errorf("Expected a disabled schema file, got %v", export.Destination.SchemaFile)
+	}
+	if want := newGCSSideFile(true, types.StringValue("csv"), types.StringValue("summaries/{runId}")); !export.Destination.SummaryFile.Equal(want) {
+		t.Errorf("Expected the summary file from the API, got %v", export.Destination.SummaryFile)
+	}
+}
+
+func TestGCSSideFileValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      types.Object
+		wantError bool
+	}{
+		{name: "enabled", file: newGCSSideFile(true, types.StringValue("json"), types.StringNull())},
+		{name: "disabled", file: newGCSSideFile(false, types.StringNull(), types.StringNull())},
+		{name: "disabled with format", file: newGCSSideFile(false, types.StringValue("json"), types.StringNull()), wantError: true},
+		{name: "disabled with id template", file: newGCSSideFile(false, types.StringNull(), types.StringValue("{runId}/schema")), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &validator.ObjectResponse{}
+			gcsSideFileValidator{}.ValidateObject(context.Background(), validator.ObjectRequest{Path: path.Root("destination").AtName("schema_file"), ConfigValue: tt.file}, resp)
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestGCSSideFileDefault(t *testing.T) {
+	ctx := context.Background()
+	r := GCSResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	formatPath := path.Root("destination").AtName("schema_file").AtName("format")
+
+	tests := []struct {
+		name   string
+		file   types.Object
+		format types.String
+		want   types.String
+	}{
+		{name: "enabled", file: newGCSSideFile(true, types.StringNull(), types.StringNull()), format: types.StringNull(), want: types.StringValue(gcsSchemaFileFormat)},
+		{
+			name:   "enabled by default",
+			file:   types.ObjectValueMust(gcsSideFileAttributeTypes, map[string]attr.Value{"enabled": types.BoolNull(), "format": types.StringNull(), "id_template": types.StringValue("{runId}/schema")}),
+			format: types.StringNull(),
+			want:   types.StringValue(gcsSchemaFileFormat),
+		},
+		{name: "disabled", file: newGCSSideFile(false, types.StringNull(), types.StringNull()), format: types.StringNull(), want: types.StringNull()},
+		{name: "configured", file: newGCSSideFile(true, types.StringValue("json"), types.StringNull()), format: types.StringValue("json"), want: types.StringValue("json")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newGCSTestModel()
+			data.Destination.SchemaFile = tt.file
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			plan := tfsdk.Plan(config)
+			if diags := plan.Set(ctx, data); diags.HasError() {
+				t.Fatalf("could not build configuration: %v", diags)
+			}
+			config.Raw = plan.Raw
+
+			// The framework plans a computed attribute that isn't configured as unknown
+			planValue := tt.format
+			if planValue.IsNull() {
+				planValue = types.StringUnknown()
+			}
+
+			resp := &planmodifier.StringResponse{PlanValue: planValue}
+			gcsSideFileDefault(gcsSchemaFileFormat).PlanModifyString(ctx, planmodifier.StringRequest{Path: formatPath, Config: config, ConfigValue: tt.format, PlanValue: planValue}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, resp.PlanValue)
+			}
+		})
+	}
+}
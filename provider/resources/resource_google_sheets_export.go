@@ -0,0 +1,207 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// googleSheetsExportDestination is the Google Sheets destination of funnel_google_sheets_export and funnel_export.
+var googleSheetsExportDestination = exportDestinationAdapter[GoogleSheetsResourceModel, ExportGoogleSheetsDestination, FunnelGoogleSheetsJSON]{
+	name:        "google_sheets",
+	apiType:     "google_sheets",
+	schema:      googleSheetsDestinationAttribute,
+	validators:  googleSheetsDestinationValidators,
+	title:       "Google Sheets",
+	description: "Google Sheets export to a sheet of a spreadsheet. Sheets only take CSV or TSV formatted data without partitioning.",
+	newModel: func(shared common.ExportShared, destination ExportGoogleSheetsDestination) GoogleSheetsResourceModel {
+		return GoogleSheetsResourceModel{ExportShared: shared, Destination: destination}
+	},
+	parts: func(model GoogleSheetsResourceModel) (common.ExportShared, ExportGoogleSheetsDestination) {
+		return model.ExportShared, model.Destination
+	},
+	exportId: func(respObj FunnelGoogleSheetsJSON) string { return respObj.Id },
+	get:      getGoogleSheetsExport,
+	prepare:  prepareGoogleSheetsExportData,
+}
+
+func NewGoogleSheetsResource() resource.Resource {
+	return newExportDestinationResource(googleSheetsExportDestination)
+}
+
+type ExportGoogleSheetsDestination struct {
+	SpreadsheetId  types.String `tfsdk:"spreadsheet_id"`
+	SheetName      types.String `tfsdk:"sheet_name"`
+	WriteMode      types.String `tfsdk:"write_mode"`
+	CredentialsRef types.String `tfsdk:"credentials_ref"`
+}
+
+type GoogleSheetsResourceModel struct {
+	Destination ExportGoogleSheetsDestination `tfsdk:"destination"`
+	common.ExportShared
+}
+
+type FunnelGoogleSheetsDestinationJSON struct {
+	Type           string `json:"type"`
+	SpreadsheetId  string `json:"spreadsheetId"`
+	SheetName      string `json:"sheetName"`
+	WriteMode      string `json:"writeMode"`
+	CredentialsRef string `json:"credentialsRef"`
+}
+
+type FunnelGoogleSheetsJSON struct {
+	Destination FunnelGoogleSheetsDestinationJSON `json:"destination"`
+	common.ExportSharedJSON
+}
+
+// googleSheetsDestinationAttribute is the Google Sheets destination, shared with the google_sheets block of funnel_export.
+func googleSheetsDestinationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Google Sheets destination sheet",
+		Required:            true,
+		Attributes: map[string]schema.Attribute{
+			"spreadsheet_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the spreadsheet from its URL, `https://docs.google.com/spreadsheets/d/<spreadsheet_id>/edit`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z0-9_-]+$`),
+						"must be the ID of the spreadsheet from its URL, not the URL itself",
+					),
+				},
+			},
+			"sheet_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the sheet (tab) in the spreadsheet to export data to",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+				},
+			},
+			"write_mode": schema.StringAttribute{
+				MarkdownDescription: "Whether each export run replaces the data of the sheet or appends its rows (`replace` or `append`)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("replace", "append"),
+				},
+			},
+			"credentials_ref": schema.StringAttribute{
+				MarkdownDescription: "Reference to Google credentials with edit access to the spreadsheet",
+				Required:            true,
+			},
+		},
+	}
+}
+
+// googleSheetsDestinationValidators only allow the format and partitioning a sheet can take when the destination is set.
+func googleSheetsDestinationValidators(destination path.Path) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		googleSheetsFormatValidator{destination: destination},
+	}
+}
+
+// googleSheetsFormatTypes are the format types that can be written to a sheet.
+var googleSheetsFormatTypes = []string{"csv", "tsv"}
+
+// googleSheetsFormatValidator rejects the format types and partition schemas that make no sense for a sheet. The rules
+// are on the shared format and partition_schema attributes, so they only apply when the destination is set.
+type googleSheetsFormatValidator struct {
+	destination path.Path
+}
+
+func (v googleSheetsFormatValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Google Sheets exports require format.type %s and no partitioning", strings.Join(googleSheetsFormatTypes, " or "))
+}
+
+func (v googleSheetsFormatValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v googleSheetsFormatValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var destination attr.Value
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.destination, &destination)...)
+	if resp.Diagnostics.HasError() || destination.IsNull() {
+		return
+	}
+
+	var formatType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("format").AtName("type"), &formatType)...)
+	if !formatType.IsNull() && !formatType.IsUnknown() && !slices.Contains(googleSheetsFormatTypes, formatType.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("format").AtName("type"),
+			"Invalid Google Sheets Format",
+			fmt.Sprintf("%s, got %q.", v.Description(ctx), formatType.ValueString()),
+		)
+	}
+
+	var partitionBy types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("partition_schema").AtName("by"), &partitionBy)...)
+	if !partitionBy.IsNull() && !partitionBy.IsUnknown() && partitionBy.ValueString() != "none" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("partition_schema").AtName("by"),
+			"Invalid Google Sheets Partitioning",
+			fmt.Sprintf("%s, got partition_schema.by %q. Leave partition_schema out or set by to none.", v.Description(ctx), partitionBy.ValueString()),
+		)
+	}
+}
+
+func getGoogleSheetsExport(ctx context.Context, client *funnel.Client, accountId string, id string) (*GoogleSheetsResourceModel, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelGoogleSheetsJSON](ctx, "exports", client, accountId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate that the export is a Google Sheets export
+	if respObj.Destination.Type != "google_sheets" {
+		return nil, fmt.Errorf("export %s is not a Google Sheets export (type: %s)", id, respObj.Destination.Type)
+	}
+
+	respObj.Fields = respObj.Query.Fields
+	respObj.Range = respObj.Query.Range
+	// If not provided, the Exports API sets currency to "*" to pick up the workspace default currency
+	if respObj.Currency == "*" {
+		respObj.Currency = ""
+	}
+	respObj.Filters = common.ConvertFiltersFromMeld(respObj.Query.Where)
+
+	export, err := common.ConvertJSONToTF[FunnelGoogleSheetsJSON, GoogleSheetsResourceModel](respObj)
+	if err != nil {
+		return nil, err
+	}
+	export.SetFiltersFromMeld(respObj.Query.Where)
+
+	return &export, nil
+}
+
+// Mutating the Google Sheets export data before sending to the API with defaults and conversions.
+func prepareGoogleSheetsExportData(ctx context.Context, data *FunnelGoogleSheetsJSON, model GoogleSheetsResourceModel) error {
+	mapped_filters, err := common.ConvertWhereToMeld(data.Filters, model.ExportShared)
+	if err != nil {
+		return err
+	}
+
+	data.OnlyAllowEditFromAPI = true
+	data.Type = "google_sheets"
+	data.Destination.Type = "google_sheets"
+	// A sheet can't be partitioned
+	data.PartitionSchema = common.PartitionSchemaJSON{By: "none"}
+	data.Query = common.QueryJSON{
+		Fields: data.Fields,
+		Range:  data.Range,
+		Where:  mapped_filters,
+	}
+	data.Format.Headers = "safename"
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"terraform-provider-funnel/provider/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newGoogleSheetsTestModel() GoogleSheetsResourceModel {
+	return GoogleSheetsResourceModel{
+		ExportShared: common.ExportShared{
+			Name:      types.StringValue("test-export"),
+			Workspace: types.StringValue("test-workspace"),
+			Schedule:  types.StringValue("0 6 * * *"),
+			Format: common.ExportFormat{
+				Type:    types.StringValue("csv"),
+				Metrics: types.StringValue("export"),
+			},
+			Range:    common.ExportRange{Start: types.StringValue("2024-01-01")},
+			Timeouts: common.NullTimeouts(),
+		},
+		Destination: ExportGoogleSheetsDestination{
+			SpreadsheetId:  types.StringValue("1BxiMVs0XRA5nFMdKvBdBZjgmUUqptlbs74OgvE2upms"),
+			SheetName:      types.StringValue("Daily rollup"),
+			WriteMode:      types.StringValue("replace"),
+			CredentialsRef: types.StringValue("google-credentials"),
+		},
+	}
+}
+
+func TestGoogleSheetsExport_Create(t *testing.T) {
+	var sent FunnelGoogleSheetsJSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123"}`))
+	})
+
+	if _, err := googleSheetsExportDestination.createModel(context.Background(), client, newGoogleSheetsTestModel()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if sent.Type != "google_sheets" || sent.Destination.Type != "google_sheets" {
+		t.Errorf("Expected export and destination type google_sheets, got %q and %q", sent.Type, sent.Destination.Type)
+	}
+	if sent.Destination.SheetName != "Daily rollup" || sent.Destination.WriteMode != "replace" {
+		t.Errorf("Expected the sheet and write mode to be sent, got %q and %q", sent.Destination.SheetName, sent.Destination.WriteMode)
+	}
+	if sent.PartitionSchema.By != "none" {
+		t.Errorf("Expected no partitioning, got %q", sent.PartitionSchema.By)
+	}
+}
+
+func TestGoogleSheetsExport_Get(t *testing.T) {
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "export-123",
+			"name": "test-export",
+			"format": {"type": "csv", "metrics": "export"},
+			"destination": {"type": "google_sheets", "spreadsheetId": "1BxiMVs0XRA5nFMdKvBdBZjgmUUqptlbs74OgvE2upms", "sheetName": "Daily rollup", "writeMode": "append", "credentialsRef": "google-credentials"}
+		}`))
+	})
+
+	export, err := getGoogleSheetsExport(context.Background(), client, "test-workspace", "export-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if export.Destination.WriteMode.ValueString() != "append" || export.Destination.CredentialsRef.ValueString() != "google-credentials" {
+		t.Errorf("Expected the write mode and credentials from the API, got %v", export.Destination)
+	}
+}
+
+func TestGoogleSheetsResource_ConfigValidators_Format(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*GoogleSheetsResourceModel)
+		wantPath  path.Path
+	}{
+		{name: "csv", configure: func(m *GoogleSheetsResourceModel) {}},
+		{name: "tsv", configure: func(m *GoogleSheetsResourceModel) { m.Format.Type = types.StringValue("tsv") }},
+		{
+			name: "no partitioning",
+			configure: func(m *GoogleSheetsResourceModel) {
+				m.PartitionSchema = common.PartitionSchema{By: types.StringValue("none"), Per: types.StringValue("all")}
+			},
+		},
+		{
+			name:      "parquet",
+			configure: func(m *GoogleSheetsResourceModel) { m.Format.Type = types.StringValue("parquet") },
+			wantPath:  path.Root("format").AtName("type"),
+		},
+		{
+			name: "partitioned by date",
+			configure: func(m *GoogleSheetsResourceModel) {
+				m.PartitionSchema = common.PartitionSchema{By: types.StringValue("date"), Per: types.StringValue("day")}
+			},
+			wantPath: path.Root("partition_schema").AtName("by"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newGoogleSheetsTestModel()
+			tt.configure(&data)
+
+			diags := validateResourceConfig(t, newExportDestinationResource(googleSheetsExportDestination), data)
+			if len(tt.wantPath.Steps()) == 0 {
+				if diags.HasError() {
+					t.Errorf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(tt.wantPath) {
+				t.Errorf("expected an error on %s, got %v", tt.wantPath, diags.Errors()[0])
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"terraform-provider-funnel/provider/common"
 	"terraform-provider-funnel/provider/funnel"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -15,13 +17,15 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &MeasurementResource{}
 var _ resource.ResourceWithImportState = &MeasurementResource{}
+var _ resource.ResourceWithConfigValidators = &MeasurementResource{}
+var _ resource.ResourceWithModifyPlan = &MeasurementResource{}
 
 func NewMeasurementResource() resource.Resource {
 	return &MeasurementResource{}
 }
 
 type MeasurementResource struct {
-	config *common.FunnelProviderModel
+	client *funnel.Client
 }
 
 type ExportMeasurementDestination struct {
@@ -65,35 +69,53 @@ func (r *MeasurementResource) Schema(ctx context.Context, req resource.SchemaReq
 		"Use `table_name` to identify the target table." + "\n" +
 		"Snapshot-related attributes (`snapshot_table_id`, `snapshot_source_id`, `snapshot_source_type`) are optional. To create a snapshot, all snapshot properties have to be set."
 
-	resp.Schema = common.GetExportSchema(
-		schema.SingleNestedAttribute{
-			MarkdownDescription: "Export destination object",
-			Required:            true,
-			Attributes: map[string]schema.Attribute{
-				"table_name": schema.StringAttribute{
-					MarkdownDescription: "Measurement table name",
-					Description:         "Measurement table name",
-					Required:            true,
-				},
-				"snapshot_table_id": schema.StringAttribute{
-					MarkdownDescription: "Snapshot table ID",
-					Description:         "Snapshot table ID",
-					Optional:            true,
-				},
-				"snapshot_source_id": schema.StringAttribute{
-					MarkdownDescription: "Snapshot source ID",
-					Description:         "Snapshot source ID",
-					Optional:            true,
-				},
-				"snapshot_source_type": schema.StringAttribute{
-					MarkdownDescription: "Snapshot source type",
-					Description:         "Snapshot source type",
-					Optional:            true,
-				},
+	resp.Schema = common.GetExportSchema(ctx, measurementDestinationAttribute(), type_description)
+}
+
+// measurementDestinationAttribute is the Measurement destination, shared with the measurement block of funnel_export.
+func measurementDestinationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Export destination object",
+		Required:            true,
+		Attributes: map[string]schema.Attribute{
+			"table_name": schema.StringAttribute{
+				MarkdownDescription: "Measurement table name",
+				Description:         "Measurement table name",
+				Required:            true,
+			},
+			"snapshot_table_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot table ID",
+				Description:         "Snapshot table ID",
+				Optional:            true,
+			},
+			"snapshot_source_id": schema.StringAttribute{
+				MarkdownDescription: "Snapshot source ID",
+				Description:         "Snapshot source ID",
+				Optional:            true,
+			},
+			"snapshot_source_type": schema.StringAttribute{
+				MarkdownDescription: "Snapshot source type",
+				Description:         "Snapshot source type",
+				Optional:            true,
 			},
 		},
-		type_description,
-	)
+	}
+}
+
+func (r *MeasurementResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return append(common.ExportConfigValidators(), measurementDestinationValidators(path.Root("destination"))...)
+}
+
+// measurementDestinationValidators returns the rules of the Measurement destination at the path.
+func measurementDestinationValidators(destination path.Path) []resource.ConfigValidator {
+	// A snapshot is only created when all snapshot attributes are set
+	return []resource.ConfigValidator{
+		resourcevalidator.RequiredTogether(
+			destination.Expression().AtName("snapshot_table_id"),
+			destination.Expression().AtName("snapshot_source_id"),
+			destination.Expression().AtName("snapshot_source_type"),
+		),
+	}
 }
 
 func (r *MeasurementResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -101,16 +123,20 @@ func (r *MeasurementResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	config, ok := req.ProviderData.(*common.FunnelProviderModel)
+	client, ok := req.ProviderData.(*funnel.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *FunnelProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.config = config
+	r.client = client
+}
+
+func (r *MeasurementResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(verifyExportFields(ctx, r.client, &resp.Plan)...)
 }
 
 func (r *MeasurementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -121,7 +147,21 @@ func (r *MeasurementResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	respObj, err := createMeasurementExport(ctx, r.config, data)
+	createTimeout, diags := data.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, data.Workspace.ValueString(), data.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respObj, err := createMeasurementExport(ctx, r.client, data)
 	if err != nil {
 		if err.StatusCode == 409 {
 			resp.Diagnostics.AddError(
@@ -149,7 +189,16 @@ func (r *MeasurementResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	export, err := getMeasurementExport(ctx, r.config, data.Workspace.ValueString(), data.Id.ValueString())
+	readTimeout, diags := data.Timeouts.Read(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	export, err := getMeasurementExport(ctx, r.client, data.Workspace.ValueString(), data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Export",
@@ -165,6 +214,9 @@ func (r *MeasurementResource) Read(ctx context.Context, req resource.ReadRequest
 
 	export.Id = data.Id
 	export.Workspace = data.Workspace
+	export.Timeouts = data.Timeouts
+	export.KeepScheduleConfig(data.ExportShared)
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(data.ExportShared)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &export)...)
 }
 
@@ -176,7 +228,21 @@ func (r *MeasurementResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	_, err := updateMeasurementExport(ctx, r.config, data)
+	updateTimeout, diags := data.Timeouts.Update(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(resolveExportFieldTypes(ctx, r.client, data.Workspace.ValueString(), data.Fields)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := updateMeasurementExport(ctx, r.client, data)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Export",
@@ -201,7 +267,7 @@ func (r *MeasurementResource) ImportState(ctx context.Context, req resource.Impo
 	workspaceID := idParts[0]
 	exportID := idParts[1]
 
-	export, err := getMeasurementExport(ctx, r.config, workspaceID, exportID)
+	export, err := getMeasurementExport(ctx, r.client, workspaceID, exportID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Measurement Export",
@@ -212,6 +278,8 @@ func (r *MeasurementResource) ImportState(ctx context.Context, req resource.Impo
 
 	export.Id = types.StringValue(exportID)
 	export.Workspace = types.StringValue(workspaceID)
+	export.Timeouts = common.NullTimeouts()
+	resp.Diagnostics.Append(export.KeepFilterRepresentation(common.ExportShared{})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, export)...)
 }
 
@@ -223,7 +291,16 @@ func (r *MeasurementResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := deleteMeasurementExport(ctx, r.config, data.Workspace.ValueString(), data.Id.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := deleteMeasurementExport(ctx, r.client, data.Workspace.ValueString(), data.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Export",
@@ -233,8 +310,8 @@ func (r *MeasurementResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 }
 
-func getMeasurementExport(ctx context.Context, config *common.FunnelProviderModel, accountId string, id string) (*MeasurementResourceModel, error) {
-	respObj, err := funnel.GetWorkspaceEntity[FunnelMeasurementJSON](ctx, "exports", config, accountId, id)
+func getMeasurementExport(ctx context.Context, client *funnel.Client, accountId string, id string) (*MeasurementResourceModel, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelMeasurementJSON](ctx, "exports", client, accountId, id)
 	if err != nil {
 		return nil, err
 	}
@@ -258,6 +335,7 @@ func getMeasurementExport(ctx context.Context, config *common.FunnelProviderMode
 	if err != nil {
 		return nil, err
 	}
+	export.SetFiltersFromMeld(respObj.Query.Where)
 
 	if respObj.Snapshot != nil {
 		export.Destination.SnapshotTableId = types.StringValue(respObj.Snapshot.SnapshotTableId)
@@ -271,33 +349,42 @@ func getMeasurementExport(ctx context.Context, config *common.FunnelProviderMode
 	return &export, nil
 }
 
-func createMeasurementExport(ctx context.Context, config *common.FunnelProviderModel, model MeasurementResourceModel) (FunnelMeasurementJSON, *funnel.APIError) {
+func createMeasurementExport(ctx context.Context, client *funnel.Client, model MeasurementResourceModel) (FunnelMeasurementJSON, *funnel.APIError) {
 	data, err := common.ConvertTFToJSON[MeasurementResourceModel, FunnelMeasurementJSON](model)
 	if err != nil {
 		return FunnelMeasurementJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
 	}
 
-	prepareMeasurementExportData(&data, model)
+	if err := prepareMeasurementExportData(&data, model); err != nil {
+		return FunnelMeasurementJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
+	}
 
-	return funnel.CreateWorkspaceEntity[FunnelMeasurementJSON, FunnelMeasurementJSON](ctx, "exports", config, model.Workspace.ValueString(), data)
+	return funnel.CreateWorkspaceEntity[FunnelMeasurementJSON, FunnelMeasurementJSON](ctx, "exports", client, model.Workspace.ValueString(), data)
 }
 
-func updateMeasurementExport(ctx context.Context, config *common.FunnelProviderModel, model MeasurementResourceModel) (FunnelMeasurementJSON, error) {
+func updateMeasurementExport(ctx context.Context, client *funnel.Client, model MeasurementResourceModel) (FunnelMeasurementJSON, error) {
 	data, err := common.ConvertTFToJSON[MeasurementResourceModel, FunnelMeasurementJSON](model)
 	if err != nil {
 		return FunnelMeasurementJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
 	}
 
-	prepareMeasurementExportData(&data, model)
+	if err := prepareMeasurementExportData(&data, model); err != nil {
+		return FunnelMeasurementJSON{}, &funnel.APIError{Message: fmt.Sprintf("Could not convert to API format: %v", err)}
+	}
 
-	return funnel.UpdateWorkspaceEntity[FunnelMeasurementJSON, FunnelMeasurementJSON](ctx, "exports", config, model.Workspace.ValueString(), model.Id.ValueString(), data)
+	return funnel.UpdateWorkspaceEntity[FunnelMeasurementJSON, FunnelMeasurementJSON](ctx, "exports", client, model.Workspace.ValueString(), model.Id.ValueString(), data)
 }
 
-func deleteMeasurementExport(ctx context.Context, config *common.FunnelProviderModel, accountId string, id string) error {
-	return funnel.DeleteWorkspaceEntity(ctx, "exports", config, accountId, id)
+func deleteMeasurementExport(ctx context.Context, client *funnel.Client, accountId string, id string) error {
+	return funnel.DeleteWorkspaceEntity(ctx, "exports", client, accountId, id)
 }
 
-func prepareMeasurementExportData(data *FunnelMeasurementJSON, model MeasurementResourceModel) {
+func prepareMeasurementExportData(data *FunnelMeasurementJSON, model MeasurementResourceModel) error {
+	where, err := common.ConvertWhereToMeld(data.Filters, model.ExportShared)
+	if err != nil {
+		return err
+	}
+
 	data.OnlyAllowEditFromAPI = true
 	data.Hidden = true
 	data.Type = "iceberg"
@@ -318,10 +405,12 @@ func prepareMeasurementExportData(data *FunnelMeasurementJSON, model Measurement
 	data.Query = common.QueryJSON{
 		Fields: data.Fields,
 		Range:  data.Range,
-		Where:  common.ConvertFiltersToMeld(data.Filters),
+		Where:  where,
 	}
 	data.Format.Headers = "safename"
 	if data.Format.Type == "parquet" {
 		data.Format.Type = "raw"
 	}
+
+	return nil
 }
@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"testing"
+
+	"terraform-provider-funnel/provider/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMeasurementResource_ConfigValidators_Snapshot(t *testing.T) {
+	newModel := func(destination ExportMeasurementDestination) MeasurementResourceModel {
+		destination.TableName = types.StringValue("measurement_table")
+		return MeasurementResourceModel{
+			Destination: destination,
+			ExportShared: common.ExportShared{
+				Schedule: types.StringValue("0 6 * * *"),
+				Range:    common.ExportRange{Start: types.StringValue("2024-01-01")},
+				Timeouts: common.NullTimeouts(),
+			},
+		}
+	}
+
+	complete := newModel(ExportMeasurementDestination{
+		SnapshotTableId:    types.StringValue("snapshot-table"),
+		SnapshotSourceId:   types.StringValue("source-id"),
+		SnapshotSourceType: types.StringValue("source-type"),
+	})
+	if diags := validateResourceConfig(t, &MeasurementResource{}, complete); diags.HasError() {
+		t.Fatalf("unexpected diagnostics for a complete snapshot: %v", diags)
+	}
+
+	if diags := validateResourceConfig(t, &MeasurementResource{}, newModel(ExportMeasurementDestination{})); diags.HasError() {
+		t.Fatalf("unexpected diagnostics without a snapshot: %v", diags)
+	}
+
+	partial := newModel(ExportMeasurementDestination{SnapshotTableId: types.StringValue("snapshot-table")})
+	diags := validateResourceConfig(t, &MeasurementResource{}, partial)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error for a partial snapshot, got %v", diags)
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("destination").AtName("snapshot_table_id")) {
+		t.Errorf("expected an error on destination.snapshot_table_id, got %v", diags.Errors()[0])
+	}
+}
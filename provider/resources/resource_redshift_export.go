@@ -0,0 +1,226 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// redshiftExportDestination is the Redshift destination of funnel_redshift_export and funnel_export.
+var redshiftExportDestination = exportDestinationAdapter[RedshiftResourceModel, ExportRedshiftDestination, FunnelRedshiftJSON]{
+	name:        "redshift",
+	apiType:     "redshift",
+	schema:      redshiftDestinationAttribute,
+	validators:  redshiftDestinationValidators,
+	title:       "Redshift",
+	description: "Amazon Redshift export. Authenticate with either a database password or an IAM role.",
+	newModel: func(shared common.ExportShared, destination ExportRedshiftDestination) RedshiftResourceModel {
+		return RedshiftResourceModel{ExportShared: shared, Destination: destination}
+	},
+	parts: func(model RedshiftResourceModel) (common.ExportShared, ExportRedshiftDestination) {
+		return model.ExportShared, model.Destination
+	},
+	exportId:        func(respObj FunnelRedshiftJSON) string { return respObj.Id },
+	get:             getRedshiftExport,
+	prepare:         prepareRedshiftExportData,
+	credentialNames: redshiftWriteOnlyCredentialNames,
+	withCredentials: withRedshiftWriteOnlyCredentials,
+	keepState:       keepRedshiftWriteOnlyState,
+}
+
+func NewRedshiftResource() resource.Resource {
+	return newExportDestinationResource(redshiftExportDestination)
+}
+
+type ExportRedshiftDestination struct {
+	ClusterEndpoint      types.String `tfsdk:"cluster_endpoint"`
+	Database             types.String `tfsdk:"database"`
+	SchemaName           types.String `tfsdk:"schema_name"`
+	TableName            types.String `tfsdk:"table_name"`
+	Username             types.String `tfsdk:"username"`
+	Password             types.String `tfsdk:"password"`
+	IAMRoleArn           types.String `tfsdk:"iam_role_arn"`
+	PasswordWO           types.String `tfsdk:"password_wo"`
+	CredentialsWOVersion types.Int64  `tfsdk:"credentials_wo_version"`
+}
+
+type RedshiftResourceModel struct {
+	Destination ExportRedshiftDestination `tfsdk:"destination"`
+	common.ExportShared
+}
+
+type FunnelRedshiftDestinationJSON struct {
+	Type            string `json:"type"`
+	ClusterEndpoint string `json:"clusterEndpoint"`
+	Database        string `json:"database"`
+	SchemaName      string `json:"schemaName"`
+	TableName       string `json:"tableName"`
+	Username        string `json:"username"`
+	Password        string `json:"password,omitempty"`
+	IAMRoleArn      string `json:"iamRoleArn,omitempty"`
+}
+
+type FunnelRedshiftJSON struct {
+	Destination FunnelRedshiftDestinationJSON `json:"destination"`
+	common.ExportSharedJSON
+}
+
+// redshiftDestinationAttribute is the Redshift destination, shared with the redshift block of funnel_export.
+func redshiftDestinationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Redshift destination table",
+		Required:    true,
+		Attributes: map[string]schema.Attribute{
+			"cluster_endpoint": schema.StringAttribute{
+				Description: "Endpoint of the Redshift cluster with its port, e.g. examplecluster.abc123xyz789.us-west-2.redshift.amazonaws.com:5439",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z0-9.-]+:\d+$`),
+						"must be a host name and port, e.g. examplecluster.abc123xyz789.us-west-2.redshift.amazonaws.com:5439",
+					),
+				},
+			},
+			"database": schema.StringAttribute{
+				Description: "Database name to export data to",
+				Required:    true,
+			},
+			"schema_name": schema.StringAttribute{
+				Description: "Schema name to export data to",
+				Required:    true,
+			},
+			"table_name": schema.StringAttribute{
+				Description: "Table name to export data to",
+				Required:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "Database user to export data as",
+				Required:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the database user",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"iam_role_arn": schema.StringAttribute{
+				Description: "ARN of the IAM role that Funnel assumes to get temporary credentials of the database user",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/.+$`),
+						"must be an IAM role ARN, e.g. arn:aws:iam::123456789012:role/funnel-export",
+					),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				Description: "Write-only password of the database user that is never stored in the plan or state. Requires Terraform 1.11 or later and credentials_wo_version.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("password")),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("credentials_wo_version")),
+				},
+			},
+			"credentials_wo_version": schema.Int64Attribute{
+				Description: "Version of the write-only credentials. Terraform can't detect changes of write-only values, so bump the version to send rotated credentials to Funnel.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// redshiftDestinationValidators returns the rules of the Redshift destination at the path.
+func redshiftDestinationValidators(destination path.Path) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		common.ExactlyOneOfAttributes(destination, "password", "password_wo", "iam_role_arn"),
+		resourcevalidator.PreferWriteOnlyAttribute(
+			destination.Expression().AtName("password"),
+			destination.Expression().AtName("password_wo"),
+		),
+	}
+}
+
+func getRedshiftExport(ctx context.Context, client *funnel.Client, accountId string, id string) (*RedshiftResourceModel, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelRedshiftJSON](ctx, "exports", client, accountId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate that the export is a Redshift export
+	if respObj.Destination.Type != "redshift" {
+		return nil, fmt.Errorf("export %s is not a Redshift export (type: %s)", id, respObj.Destination.Type)
+	}
+
+	respObj.Fields = respObj.Query.Fields
+	respObj.Range = respObj.Query.Range
+	if respObj.Format.Type == "raw" {
+		respObj.Format.Type = "parquet"
+	}
+	// If not provided, the Exports API sets currency to "*" to pick up the workspace default currency
+	if respObj.Currency == "*" {
+		respObj.Currency = ""
+	}
+
+	respObj.Filters = common.ConvertFiltersFromMeld(respObj.Query.Where)
+
+	export, err := common.ConvertJSONToTF[FunnelRedshiftJSON, RedshiftResourceModel](respObj)
+	if err != nil {
+		return nil, err
+	}
+	export.SetFiltersFromMeld(respObj.Query.Where)
+
+	nullUnsetAuthentication(respObj.Destination.Password, &export.Destination.Password)
+	nullUnsetAuthentication(respObj.Destination.IAMRoleArn, &export.Destination.IAMRoleArn)
+
+	return &export, nil
+}
+
+// redshiftWriteOnlyCredentialNames are the credentials of the Redshift destination that can be write-only.
+var redshiftWriteOnlyCredentialNames = []string{"password"}
+
+// withRedshiftWriteOnlyCredentials returns a copy of the model to send to the API with the write-only password in place of the stored one.
+func withRedshiftWriteOnlyCredentials(model RedshiftResourceModel, credentials writeOnlyCredentials) RedshiftResourceModel {
+	model.Destination.Password = credentials.replace("password", model.Destination.Password)
+	return model
+}
+
+// keepRedshiftWriteOnlyState keeps the prior password of a Redshift destination with a write-only password.
+func keepRedshiftWriteOnlyState(destination *ExportRedshiftDestination, prior ExportRedshiftDestination) {
+	if keepWriteOnlyVersion(&destination.CredentialsWOVersion, prior.CredentialsWOVersion) {
+		destination.Password = prior.Password
+	}
+}
+
+// Mutating the Redshift export data before sending to the API with defaults and conversions.
+func prepareRedshiftExportData(ctx context.Context, data *FunnelRedshiftJSON, model RedshiftResourceModel) error {
+	mapped_filters, err := common.ConvertWhereToMeld(data.Filters, model.ExportShared)
+	if err != nil {
+		return err
+	}
+
+	data.OnlyAllowEditFromAPI = true
+	data.Type = "redshift"
+	data.Destination.Type = "redshift"
+	data.Query = common.QueryJSON{
+		Fields: data.Fields,
+		Range:  data.Range,
+		Where:  mapped_filters,
+	}
+	data.Format.Headers = "safename"
+	if data.Format.Type == "parquet" {
+		data.Format.Type = "raw"
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"terraform-provider-funnel/provider/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func newRedshiftTestModel() RedshiftResourceModel {
+	return RedshiftResourceModel{
+		ExportShared: common.ExportShared{
+			Name:      types.StringValue("test-export"),
+			Workspace: types.StringValue("test-workspace"),
+			Schedule:  types.StringValue("0 6 * * *"),
+			Format: common.ExportFormat{
+				Type:    types.StringValue("csv"),
+				Metrics: types.StringValue("export"),
+			},
+			Range:    common.ExportRange{Start: types.StringValue("2024-01-01")},
+			Timeouts: common.NullTimeouts(),
+		},
+		Destination: ExportRedshiftDestination{
+			ClusterEndpoint:      types.StringValue("examplecluster.abc123xyz789.us-west-2.redshift.amazonaws.com:5439"),
+			Database:             types.StringValue("finance"),
+			SchemaName:           types.StringValue("marketing"),
+			TableName:            types.StringValue("funnel"),
+			Username:             types.StringValue("funnel_user"),
+			PasswordWO:           types.StringValue("wo-password"),
+			CredentialsWOVersion: types.Int64Value(1),
+		},
+	}
+}
+
+func TestRedshiftExport_SendsWriteOnlyCredentials(t *testing.T) {
+	var sent FunnelRedshiftJSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123"}`))
+	})
+
+	data := newRedshiftTestModel()
+	credentials := writeOnlyCredentials{"password": types.StringValue("wo-password")}
+
+	if _, err := redshiftExportDestination.createModel(context.Background(), client, withRedshiftWriteOnlyCredentials(data, credentials)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if sent.Destination.Type != "redshift" {
+		t.Errorf("Expected destination type redshift, got %q", sent.Destination.Type)
+	}
+	if sent.Destination.Password != "wo-password" {
+		t.Errorf("Expected the write-only password to be sent, got %q", sent.Destination.Password)
+	}
+	if !data.Destination.Password.IsNull() {
+		t.Error("Expected the model saved to state to have no password")
+	}
+}
+
+func TestRedshiftResource_ImportState(t *testing.T) {
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "export-123",
+			"name": "test-export",
+			"schedule": "0 6 * * *",
+			"format": {"type": "csv", "metrics": "export"},
+			"destination": {"type": "redshift", "clusterEndpoint": "examplecluster.abc123xyz789.us-west-2.redshift.amazonaws.com:5439", "database": "finance", "schemaName": "marketing", "tableName": "funnel", "username": "funnel_user", "iamRoleArn": "arn:aws:iam::123456789012:role/funnel-export"}
+		}`))
+	})
+
+	ctx := context.Background()
+	r := newExportDestinationResource(redshiftExportDestination)
+	r.client = client
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "test-workspace/export-123"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got %v", resp.Diagnostics)
+	}
+
+	var data RedshiftResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if data.Workspace.ValueString() != "test-workspace" || data.Id.ValueString() != "export-123" {
+		t.Errorf("Expected the workspace and ID of the import ID, got %v and %v", data.Workspace, data.Id)
+	}
+	if data.Destination.IAMRoleArn.ValueString() != "arn:aws:iam::123456789012:role/funnel-export" {
+		t.Errorf("Expected the IAM role from the API, got %v", data.Destination.IAMRoleArn)
+	}
+	if !data.Destination.Password.IsNull() || !data.Destination.CredentialsWOVersion.IsNull() {
+		t.Errorf("Expected no password, got %v and %v", data.Destination.Password, data.Destination.CredentialsWOVersion)
+	}
+}
+
+func TestRedshiftResource_ConfigValidators_Credentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*ExportRedshiftDestination)
+		wantError bool
+	}{
+		{name: "write-only password", configure: func(d *ExportRedshiftDestination) {}},
+		{
+			name: "iam role",
+			configure: func(d *ExportRedshiftDestination) {
+				d.PasswordWO = types.StringNull()
+				d.IAMRoleArn = types.StringValue("arn:aws:iam::123456789012:role/funnel-export")
+			},
+		},
+		{name: "no credentials", configure: func(d *ExportRedshiftDestination) { d.PasswordWO = types.StringNull() }, wantError: true},
+		{
+			name: "password and iam role",
+			configure: func(d *ExportRedshiftDestination) {
+				d.IAMRoleArn = types.StringValue("arn:aws:iam::123456789012:role/funnel-export")
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newRedshiftTestModel()
+			tt.configure(&data.Destination)
+
+			diags := validateResourceConfig(t, newExportDestinationResource(redshiftExportDestination), data)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
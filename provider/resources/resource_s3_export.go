@@ -0,0 +1,257 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// s3ExportDestination is the S3 destination of funnel_s3_export and funnel_export.
+var s3ExportDestination = exportDestinationAdapter[FunnelS3Resource, FunnelS3Destination, FunnelS3JSON]{
+	name:        "s3",
+	apiType:     "s3",
+	schema:      s3DestinationAttribute,
+	validators:  s3DestinationValidators,
+	title:       "S3",
+	description: "Amazon S3 export",
+	newModel: func(shared common.ExportShared, destination FunnelS3Destination) FunnelS3Resource {
+		return FunnelS3Resource{ExportShared: shared, Destination: destination}
+	},
+	parts: func(model FunnelS3Resource) (common.ExportShared, FunnelS3Destination) {
+		return model.ExportShared, model.Destination
+	},
+	exportId: func(respObj FunnelS3JSON) string { return respObj.Id },
+	get:      getS3Export,
+	prepare:  prepareS3ExportData,
+	fromAPI:  setS3DestinationFromAPI,
+}
+
+func NewS3Resource() resource.Resource {
+	return newExportDestinationResource(s3ExportDestination)
+}
+
+type FunnelS3Destination struct {
+	OutputIdTemplate types.String `tfsdk:"output_id_template"`
+	Path             types.String `tfsdk:"path"`
+	Bucket           types.String `tfsdk:"bucket"`
+	Region           types.String `tfsdk:"region"`
+	GZip             types.Bool   `tfsdk:"gzip"`
+	RoleArn          types.String `tfsdk:"role_arn"`
+	ExternalId       types.String `tfsdk:"external_id"`
+	CredentialsRef   types.String `tfsdk:"credentials_ref"`
+	Headers          types.String `tfsdk:"headers"`
+	// Objects since they are computed as a whole when not configured.
+	SchemaFile  types.Object `tfsdk:"schema_file"`
+	SummaryFile types.Object `tfsdk:"summary_file"`
+}
+
+type FunnelS3Resource struct {
+	Destination FunnelS3Destination `tfsdk:"destination"`
+	common.ExportShared
+}
+
+type FunnelS3DestinationJSON struct {
+	Type             string `json:"type"`
+	OutputIdTemplate string `json:"outputIdTemplate"`
+	Path             string `json:"path"`
+	Bucket           string `json:"bucket"`
+	Region           string `json:"region"`
+	GZip             bool   `json:"gzip"`
+	RoleArn          string `json:"roleArn,omitempty"`
+	ExternalId       string `json:"externalId,omitempty"`
+	CredentialsRef   string `json:"credentialsRef,omitempty"`
+	// Empty when the side file is disabled.
+	SummaryFileFormat     string `json:"summaryFileFormat"`
+	SummaryFileIdTemplate string `json:"summaryFileIdTemplate"`
+	SchemaFileFormat      string `json:"schemaFileFormat"`
+	SchemaFileIdTemplate  string `json:"schemaFileIdTemplate"`
+}
+
+type FunnelS3JSON struct {
+	Destination FunnelS3DestinationJSON `json:"destination"`
+	common.ExportSharedJSON
+}
+
+// s3DestinationAttribute is the S3 destination, shared with the s3 block of funnel_export.
+func s3DestinationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Amazon S3 destination. Authenticate with either `role_arn` and `external_id` or `credentials_ref`",
+		Required:            true,
+		Attributes: map[string]schema.Attribute{
+			"output_id_template": schema.StringAttribute{
+				MarkdownDescription: "Output ID template for the export",
+				Required:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path for the export",
+				Required:            true,
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "S3 bucket for the export",
+				Required:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "AWS region of the bucket, e.g. `eu-west-1`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d$`),
+						"must be an AWS region, e.g. eu-west-1",
+					),
+				},
+			},
+			"gzip": schema.BoolAttribute{
+				MarkdownDescription: "Whether to gzip the exported files. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"role_arn": schema.StringAttribute{
+				MarkdownDescription: "ARN of the IAM role that Funnel assumes to write to the bucket",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/.+$`),
+						"must be an IAM role ARN, e.g. arn:aws:iam::123456789012:role/funnel-export",
+					),
+				},
+			},
+			"external_id": schema.StringAttribute{
+				MarkdownDescription: "External ID that Funnel passes when it assumes `role_arn`",
+				Optional:            true,
+			},
+			"credentials_ref": schema.StringAttribute{
+				MarkdownDescription: "Reference to AWS credentials secret",
+				Optional:            true,
+			},
+			// The bucket gets the same files as a GCS bucket
+			"headers": schema.StringAttribute{
+				MarkdownDescription: "Style of the column headers of the exported files, one of `safename`, `name` or `id`. Defaults to `safename`, the field names made safe for databases",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(gcsDefaultHeaders),
+				Validators: []validator.String{
+					stringvalidator.OneOf(gcsDefaultHeaders, "name", "id"),
+				},
+			},
+			"schema_file": gcsSideFileAttribute(
+				"Schema file describing the columns of the exported files",
+				gcsSchemaFileFormat,
+				gcsSchemaFileIdTemplate,
+			),
+			"summary_file": gcsSideFileAttribute(
+				"Summary file of each export run",
+				gcsSummaryFileFormat,
+				gcsSummaryFileIdTemplate,
+			),
+		},
+	}
+}
+
+// s3DestinationValidators require one way to authenticate, an IAM role with its external ID or a credentials reference.
+func s3DestinationValidators(destination path.Path) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		common.ExactlyOneOfAttributes(destination, "role_arn", "credentials_ref"),
+		resourcevalidator.RequiredTogether(
+			destination.Expression().AtName("role_arn"),
+			destination.Expression().AtName("external_id"),
+		),
+	}
+}
+
+func getS3Export(ctx context.Context, client *funnel.Client, accountId string, id string) (*FunnelS3Resource, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelS3JSON](ctx, "exports", client, accountId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate that the export is an S3 export
+	if respObj.Destination.Type != "s3" {
+		return nil, fmt.Errorf("export %s is not an S3 export (type: %s)", id, respObj.Destination.Type)
+	}
+
+	respObj.Fields = respObj.Query.Fields
+	respObj.Range = respObj.Query.Range
+	if respObj.Format.Type == "raw" {
+		respObj.Format.Type = "parquet"
+	}
+	// If not provided, the Exports API sets currency to "*" to pick up the workspace default currency
+	if respObj.Currency == "*" {
+		respObj.Currency = ""
+	}
+	respObj.Filters = common.ConvertFiltersFromMeld(respObj.Query.Where)
+
+	export, err := common.ConvertJSONToTF[FunnelS3JSON, FunnelS3Resource](respObj)
+	if err != nil {
+		return nil, err
+	}
+	export.SetFiltersFromMeld(respObj.Query.Where)
+	setS3DestinationFromAPI(&export.Destination, respObj)
+
+	nullUnsetAuthentication(respObj.Destination.RoleArn, &export.Destination.RoleArn, &export.Destination.ExternalId)
+	nullUnsetAuthentication(respObj.Destination.CredentialsRef, &export.Destination.CredentialsRef)
+
+	return &export, nil
+}
+
+// setS3DestinationFromAPI sets the destination attributes that Funnel defaults from the export returned by the API.
+func setS3DestinationFromAPI(destination *FunnelS3Destination, respObj FunnelS3JSON) {
+	destination.GZip = types.BoolValue(respObj.Destination.GZip)
+	destination.Headers = types.StringValue(respObj.Format.Headers)
+	destination.SchemaFile = gcsSideFileValue(respObj.Destination.SchemaFileFormat, respObj.Destination.SchemaFileIdTemplate)
+	destination.SummaryFile = gcsSideFileValue(respObj.Destination.SummaryFileFormat, respObj.Destination.SummaryFileIdTemplate)
+}
+
+// Mutating the S3 export data before sending to the API with defaults and conversions.
+func prepareS3ExportData(ctx context.Context, data *FunnelS3JSON, model FunnelS3Resource) error {
+	mapped_filters, err := common.ConvertWhereToMeld(data.Filters, model.ExportShared)
+	if err != nil {
+		return err
+	}
+
+	data.OnlyAllowEditFromAPI = true
+	data.Type = "s3"
+	data.Destination.Type = "s3"
+	// Files are gzipped unless gzip is set to false
+	data.Destination.GZip = model.Destination.GZip.IsNull() || model.Destination.GZip.IsUnknown() || model.Destination.GZip.ValueBool()
+
+	data.Destination.SchemaFileFormat, data.Destination.SchemaFileIdTemplate, err = gcsSideFileJSON(
+		ctx, model.Destination.SchemaFile, gcsSchemaFileFormat, gcsSchemaFileIdTemplate,
+	)
+	if err != nil {
+		return err
+	}
+	data.Destination.SummaryFileFormat, data.Destination.SummaryFileIdTemplate, err = gcsSideFileJSON(
+		ctx, model.Destination.SummaryFile, gcsSummaryFileFormat, gcsSummaryFileIdTemplate,
+	)
+	if err != nil {
+		return err
+	}
+
+	data.Query = common.QueryJSON{
+		Fields: data.Fields,
+		Range:  data.Range,
+		Where:  mapped_filters,
+	}
+	data.Format.Headers = gcsDefaultHeaders
+	if !model.Destination.Headers.IsNull() && !model.Destination.Headers.IsUnknown() {
+		data.Format.Headers = model.Destination.Headers.ValueString()
+	}
+	if data.Format.Type == "parquet" {
+		data.Format.Type = "raw"
+	}
+
+	return nil
+}
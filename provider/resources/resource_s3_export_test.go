@@ -0,0 +1,182 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-funnel/provider/auth"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newS3TestModel() FunnelS3Resource {
+	return FunnelS3Resource{
+		ExportShared: common.ExportShared{
+			Name:      types.StringValue("test-export"),
+			Workspace: types.StringValue("test-workspace"),
+			Schedule:  types.StringValue("0 6 * * *"),
+			Format: common.ExportFormat{
+				Type:    types.StringValue("parquet"),
+				Metrics: types.StringValue("export"),
+			},
+			Range:    common.ExportRange{Start: types.StringValue("2024-01-01")},
+			Timeouts: common.NullTimeouts(),
+		},
+		Destination: FunnelS3Destination{
+			OutputIdTemplate: types.StringValue("funnel_export_{date}"),
+			Path:             types.StringValue("exports"),
+			Bucket:           types.StringValue("test-bucket"),
+			Region:           types.StringValue("eu-west-1"),
+			GZip:             types.BoolUnknown(),
+			RoleArn:          types.StringValue("arn:aws:iam::123456789012:role/funnel-export"),
+			ExternalId:       types.StringValue("external-id"),
+			CredentialsRef:   types.StringNull(),
+			Headers:          types.StringUnknown(),
+			SchemaFile:       types.ObjectUnknown(gcsSideFileAttributeTypes),
+			SummaryFile:      types.ObjectUnknown(gcsSideFileAttributeTypes),
+		},
+	}
+}
+
+func newExportTestClient(t *testing.T, handler http.HandlerFunc) *funnel.Client {
+	t.Helper()
+	mockServer := httptest.NewServer(handler)
+	t.Cleanup(mockServer.Close)
+
+	return funnel.NewClient(funnel.ClientConfig{
+		Environment: mockServer.URL + "/v1",
+		TokenSource: auth.StaticTokenSource("test-token"),
+	})
+}
+
+func TestS3Export_Create(t *testing.T) {
+	var sent FunnelS3JSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123","destination":{"type":"s3","gzip":true}}`))
+	})
+
+	if _, err := s3ExportDestination.createModel(context.Background(), client, newS3TestModel()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if sent.Type != "s3" || sent.Destination.Type != "s3" {
+		t.Errorf("Expected export and destination type s3, got %q and %q", sent.Type, sent.Destination.Type)
+	}
+	if !sent.Destination.GZip {
+		t.Error("Expected gzip to default to true")
+	}
+	if sent.Destination.RoleArn != "arn:aws:iam::123456789012:role/funnel-export" || sent.Destination.ExternalId != "external-id" {
+		t.Errorf("Expected the IAM role to be sent, got %q and %q", sent.Destination.RoleArn, sent.Destination.ExternalId)
+	}
+	if sent.Format.Type != "raw" {
+		t.Errorf("Expected parquet to be sent as raw, got %q", sent.Format.Type)
+	}
+	if sent.Format.Headers != "safename" || sent.Destination.SchemaFileFormat != "sql" || sent.Destination.SummaryFileFormat != "csv" {
+		t.Errorf("Expected the default headers and side files, got %q, %q and %q", sent.Format.Headers, sent.Destination.SchemaFileFormat, sent.Destination.SummaryFileFormat)
+	}
+
+	data := newS3TestModel()
+	data.Destination.GZip = types.BoolValue(false)
+	data.Destination.Headers = types.StringValue("id")
+	data.Destination.SchemaFile = newGCSSideFile(false, types.StringNull(), types.StringNull())
+	data.Destination.SummaryFile = newGCSSideFile(true, types.StringValue("json"), types.StringUnknown())
+	if _, err := s3ExportDestination.createModel(context.Background(), client, data); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent.Destination.GZip || sent.Format.Headers != "id" {
+		t.Errorf("Expected no gzip and id headers, got %v and %q", sent.Destination.GZip, sent.Format.Headers)
+	}
+	if sent.Destination.SchemaFileFormat != "" || sent.Destination.SchemaFileIdTemplate != "" {
+		t.Errorf("Expected the schema file to be disabled, got %q and %q", sent.Destination.SchemaFileFormat, sent.Destination.SchemaFileIdTemplate)
+	}
+	if sent.Destination.SummaryFileFormat != "json" || sent.Destination.SummaryFileIdTemplate != "{runId}/funnel_summary" {
+		t.Errorf("Expected the json summary file, got %q and %q", sent.Destination.SummaryFileFormat, sent.Destination.SummaryFileIdTemplate)
+	}
+}
+
+func TestS3Export_Get(t *testing.T) {
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "export-123",
+			"name": "test-export",
+			"currency": "*",
+			"format": {"type": "raw", "metrics": "export", "headers": "safename"},
+			"destination": {"type": "s3", "bucket": "test-bucket", "path": "exports", "region": "eu-west-1", "gzip": true, "credentialsRef": "aws-credentials", "schemaFileFormat": "sql", "schemaFileIdTemplate": "{runId}/funnel_schema"}
+		}`))
+	})
+
+	export, err := getS3Export(context.Background(), client, "test-workspace", "export-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if export.Destination.Bucket.ValueString() != "test-bucket" || export.Destination.Region.ValueString() != "eu-west-1" {
+		t.Errorf("Expected bucket and region from the API, got %v", export.Destination)
+	}
+	if export.Destination.CredentialsRef.ValueString() != "aws-credentials" {
+		t.Errorf("Expected credentials_ref aws-credentials, got %v", export.Destination.CredentialsRef)
+	}
+	if !export.Destination.RoleArn.IsNull() || !export.Destination.ExternalId.IsNull() {
+		t.Errorf("Expected no IAM role, got %v and %v", export.Destination.RoleArn, export.Destination.ExternalId)
+	}
+	if export.Format.Type.ValueString() != "parquet" || export.Currency.ValueString() != "" {
+		t.Errorf("Expected format parquet and the default currency, got %v and %v", export.Format.Type, export.Currency)
+	}
+	if !export.Destination.GZip.ValueBool() || export.Destination.Headers.ValueString() != "safename" {
+		t.Errorf("Expected gzip and headers from the API, got %v and %v", export.Destination.GZip, export.Destination.Headers)
+	}
+	if want := newGCSSideFile(true, types.StringValue("sql"), types.StringValue("{runId}/funnel_schema")); !export.Destination.SchemaFile.Equal(want) {
+		t.Errorf("Expected the schema file from the API, got %v", export.Destination.SchemaFile)
+	}
+	if want := newGCSSideFile(false, types.StringNull(), types.StringNull()); !export.Destination.SummaryFile.Equal(want) {
+		t.Errorf("Expected a disabled summary file, got %v", export.Destination.SummaryFile)
+	}
+}
+
+func TestS3Resource_ConfigValidators_Authentication(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*FunnelS3Destination)
+		wantError bool
+	}{
+		{name: "iam role", configure: func(d *FunnelS3Destination) {}},
+		{
+			name: "credentials reference",
+			configure: func(d *FunnelS3Destination) {
+				d.RoleArn = types.StringNull()
+				d.ExternalId = types.StringNull()
+				d.CredentialsRef = types.StringValue("aws-credentials")
+			},
+		},
+		{
+			name: "no authentication",
+			configure: func(d *FunnelS3Destination) {
+				d.RoleArn = types.StringNull()
+				d.ExternalId = types.StringNull()
+			},
+			wantError: true,
+		},
+		{name: "iam role and credentials reference", configure: func(d *FunnelS3Destination) { d.CredentialsRef = types.StringValue("aws-credentials") }, wantError: true},
+		{name: "iam role without external id", configure: func(d *FunnelS3Destination) { d.ExternalId = types.StringNull() }, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newS3TestModel()
+			tt.configure(&data.Destination)
+
+			diags := validateResourceConfig(t, newExportDestinationResource(s3ExportDestination), data)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/funnel"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sftpExportDestination is the SFTP destination of funnel_sftp_export and funnel_export.
+var sftpExportDestination = exportDestinationAdapter[SftpResourceModel, ExportSftpDestination, FunnelSftpJSON]{
+	name:        "sftp",
+	apiType:     "sftp",
+	schema:      sftpDestinationAttribute,
+	validators:  sftpDestinationValidators,
+	title:       "SFTP",
+	description: "SFTP export. Authenticate with either a password or a private key.",
+	newModel: func(shared common.ExportShared, destination ExportSftpDestination) SftpResourceModel {
+		return SftpResourceModel{ExportShared: shared, Destination: destination}
+	},
+	parts: func(model SftpResourceModel) (common.ExportShared, ExportSftpDestination) {
+		return model.ExportShared, model.Destination
+	},
+	exportId:        func(respObj FunnelSftpJSON) string { return respObj.Id },
+	get:             getSftpExport,
+	prepare:         prepareSftpExportData,
+	credentialNames: sftpWriteOnlyCredentialNames,
+	withCredentials: withSftpWriteOnlyCredentials,
+	keepState:       keepSftpWriteOnlyState,
+	fromAPI: func(destination *ExportSftpDestination, respObj FunnelSftpJSON) {
+		destination.Port = types.Int64Value(respObj.Destination.Port)
+		destination.GZip = types.BoolValue(respObj.Destination.GZip)
+	},
+}
+
+func NewSftpResource() resource.Resource {
+	return newExportDestinationResource(sftpExportDestination)
+}
+
+type ExportSftpDestination struct {
+	Host                 types.String `tfsdk:"host"`
+	Port                 types.Int64  `tfsdk:"port"`
+	Username             types.String `tfsdk:"username"`
+	Password             types.String `tfsdk:"password"`
+	PrivateKey           types.String `tfsdk:"private_key"`
+	Path                 types.String `tfsdk:"path"`
+	HostKeyFingerprint   types.String `tfsdk:"host_key_fingerprint"`
+	GZip                 types.Bool   `tfsdk:"gzip"`
+	OutputIdTemplate     types.String `tfsdk:"output_id_template"`
+	PasswordWO           types.String `tfsdk:"password_wo"`
+	PrivateKeyWO         types.String `tfsdk:"private_key_wo"`
+	CredentialsWOVersion types.Int64  `tfsdk:"credentials_wo_version"`
+}
+
+type SftpResourceModel struct {
+	Destination ExportSftpDestination `tfsdk:"destination"`
+	common.ExportShared
+}
+
+type FunnelSftpDestinationJSON struct {
+	Type               string `json:"type"`
+	Host               string `json:"host"`
+	Port               int64  `json:"port"`
+	Username           string `json:"username"`
+	Password           string `json:"password,omitempty"`
+	PrivateKey         string `json:"privateKey,omitempty"`
+	Path               string `json:"path"`
+	HostKeyFingerprint string `json:"hostKeyFingerprint"`
+	GZip               bool   `json:"gzip"`
+	OutputIdTemplate   string `json:"outputIdTemplate"`
+}
+
+type FunnelSftpJSON struct {
+	Destination FunnelSftpDestinationJSON `json:"destination"`
+	common.ExportSharedJSON
+}
+
+// sftpDestinationAttribute is the SFTP destination, shared with the sftp block of funnel_export.
+func sftpDestinationAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "SFTP destination server",
+		Required:    true,
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				Description: "Host name or IP address of the SFTP server, without a port",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z0-9.-]+$`),
+						"must be a host name or IP address without a scheme or port, e.g. sftp.example.com",
+					),
+				},
+			},
+			"port": schema.Int64Attribute{
+				Description: "Port of the SFTP server. Defaults to 22",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "User to log in to the SFTP server as",
+				Required:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the user",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"private_key": schema.StringAttribute{
+				Description: "PEM encoded private SSH key of the user",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"path": schema.StringAttribute{
+				Description: "Remote directory to upload the files to",
+				Required:    true,
+			},
+			"host_key_fingerprint": schema.StringAttribute{
+				Description: "SHA256 fingerprint of the host key of the SFTP server that Funnel verifies before uploading, e.g. SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^SHA256:[A-Za-z0-9+/]{43}=?$`),
+						"must be a SHA256 host key fingerprint as printed by ssh-keygen -l, e.g. SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8",
+					),
+				},
+			},
+			"gzip": schema.BoolAttribute{
+				Description: "Whether to gzip the exported files. Defaults to true",
+				Optional:    true,
+				Computed:    true,
+			},
+			"output_id_template": schema.StringAttribute{
+				Description: "Template of the names of the exported files",
+				Required:    true,
+			},
+			"password_wo": schema.StringAttribute{
+				Description: "Write-only password of the user that is never stored in the plan or state. Requires Terraform 1.11 or later and credentials_wo_version.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("password")),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("credentials_wo_version")),
+				},
+			},
+			"private_key_wo": schema.StringAttribute{
+				Description: "Write-only private SSH key of the user that is never stored in the plan or state. Requires Terraform 1.11 or later and credentials_wo_version.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("private_key")),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("credentials_wo_version")),
+				},
+			},
+			"credentials_wo_version": schema.Int64Attribute{
+				Description: "Version of the write-only credentials. Terraform can't detect changes of write-only values, so bump the version to send rotated credentials to Funnel.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// sftpDestinationValidators returns the rules of the SFTP destination at the path.
+func sftpDestinationValidators(destination path.Path) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		common.ExactlyOneOfAttributes(destination, "password", "private_key", "password_wo", "private_key_wo"),
+		resourcevalidator.PreferWriteOnlyAttribute(
+			destination.Expression().AtName("password"),
+			destination.Expression().AtName("password_wo"),
+		),
+		resourcevalidator.PreferWriteOnlyAttribute(
+			destination.Expression().AtName("private_key"),
+			destination.Expression().AtName("private_key_wo"),
+		),
+	}
+}
+
+func getSftpExport(ctx context.Context, client *funnel.Client, accountId string, id string) (*SftpResourceModel, error) {
+	respObj, err := funnel.GetWorkspaceEntity[FunnelSftpJSON](ctx, "exports", client, accountId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate that the export is an SFTP export
+	if respObj.Destination.Type != "sftp" {
+		return nil, fmt.Errorf("export %s is not an SFTP export (type: %s)", id, respObj.Destination.Type)
+	}
+
+	respObj.Fields = respObj.Query.Fields
+	respObj.Range = respObj.Query.Range
+	if respObj.Format.Type == "raw" {
+		respObj.Format.Type = "parquet"
+	}
+	// If not provided, the Exports API sets currency to "*" to pick up the workspace default currency
+	if respObj.Currency == "*" {
+		respObj.Currency = ""
+	}
+	respObj.Filters = common.ConvertFiltersFromMeld(respObj.Query.Where)
+
+	export, err := common.ConvertJSONToTF[FunnelSftpJSON, SftpResourceModel](respObj)
+	if err != nil {
+		return nil, err
+	}
+	export.SetFiltersFromMeld(respObj.Query.Where)
+
+	nullUnsetAuthentication(respObj.Destination.Password, &export.Destination.Password)
+	nullUnsetAuthentication(respObj.Destination.PrivateKey, &export.Destination.PrivateKey)
+
+	return &export, nil
+}
+
+// sftpWriteOnlyCredentialNames are the credentials of the SFTP destination that can be write-only.
+var sftpWriteOnlyCredentialNames = []string{"password", "private_key"}
+
+// withSftpWriteOnlyCredentials returns a copy of the model to send to the API with the write-only password and private key in place of the stored ones.
+func withSftpWriteOnlyCredentials(model SftpResourceModel, credentials writeOnlyCredentials) SftpResourceModel {
+	model.Destination.Password = credentials.replace("password", model.Destination.Password)
+	model.Destination.PrivateKey = credentials.replace("private_key", model.Destination.PrivateKey)
+	return model
+}
+
+// keepSftpWriteOnlyState keeps the prior password and private key of an SFTP destination with write-only credentials.
+func keepSftpWriteOnlyState(destination *ExportSftpDestination, prior ExportSftpDestination) {
+	if keepWriteOnlyVersion(&destination.CredentialsWOVersion, prior.CredentialsWOVersion) {
+		destination.Password = prior.Password
+		destination.PrivateKey = prior.PrivateKey
+	}
+}
+
+// Mutating the SFTP export data before sending to the API with defaults and conversions.
+func prepareSftpExportData(ctx context.Context, data *FunnelSftpJSON, model SftpResourceModel) error {
+	mapped_filters, err := common.ConvertWhereToMeld(data.Filters, model.ExportShared)
+	if err != nil {
+		return err
+	}
+
+	data.OnlyAllowEditFromAPI = true
+	data.Type = "sftp"
+	data.Destination.Type = "sftp"
+	// The SFTP port defaults to 22 and files are gzipped unless gzip is set to false
+	if model.Destination.Port.IsNull() || model.Destination.Port.IsUnknown() {
+		data.Destination.Port = 22
+	}
+	data.Destination.GZip = model.Destination.GZip.IsNull() || model.Destination.GZip.IsUnknown() || model.Destination.GZip.ValueBool()
+	data.Query = common.QueryJSON{
+		Fields: data.Fields,
+		Range:  data.Range,
+		Where:  mapped_filters,
+	}
+	data.Format.Headers = "safename"
+	if data.Format.Type == "parquet" {
+		data.Format.Type = "raw"
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"terraform-provider-funnel/provider/common"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newSftpTestModel() SftpResourceModel {
+	return SftpResourceModel{
+		ExportShared: common.ExportShared{
+			Name:      types.StringValue("test-export"),
+			Workspace: types.StringValue("test-workspace"),
+			Schedule:  types.StringValue("0 6 * * *"),
+			Format: common.ExportFormat{
+				Type:    types.StringValue("parquet"),
+				Metrics: types.StringValue("export"),
+			},
+			Range:    common.ExportRange{Start: types.StringValue("2024-01-01")},
+			Timeouts: common.NullTimeouts(),
+		},
+		Destination: ExportSftpDestination{
+			Host:                 types.StringValue("sftp.example.com"),
+			Port:                 types.Int64Unknown(),
+			Username:             types.StringValue("funnel"),
+			Path:                 types.StringValue("/uploads/funnel"),
+			HostKeyFingerprint:   types.StringValue("SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8"),
+			GZip:                 types.BoolUnknown(),
+			OutputIdTemplate:     types.StringValue("funnel_export_{date}"),
+			PrivateKeyWO:         types.StringValue("wo-key"),
+			CredentialsWOVersion: types.Int64Value(1),
+		},
+	}
+}
+
+func TestSftpExport_Create(t *testing.T) {
+	var sent FunnelSftpJSON
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"export-123","destination":{"type":"sftp","port":22,"gzip":true}}`))
+	})
+
+	data := newSftpTestModel()
+	credentials := writeOnlyCredentials{"private_key": types.StringValue("wo-key")}
+
+	if _, err := sftpExportDestination.createModel(context.Background(), client, withSftpWriteOnlyCredentials(data, credentials)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if sent.Type != "sftp" || sent.Destination.Type != "sftp" {
+		t.Errorf("Expected export and destination type sftp, got %q and %q", sent.Type, sent.Destination.Type)
+	}
+	if sent.Destination.Port != 22 || !sent.Destination.GZip {
+		t.Errorf("Expected port 22 and gzip by default, got %d and %v", sent.Destination.Port, sent.Destination.GZip)
+	}
+	if sent.Destination.PrivateKey != "wo-key" || sent.Destination.Password != "" {
+		t.Errorf("Expected only the write-only private key to be sent, got %q and %q", sent.Destination.PrivateKey, sent.Destination.Password)
+	}
+	if sent.Format.Type != "raw" {
+		t.Errorf("Expected parquet to be sent as raw, got %q", sent.Format.Type)
+	}
+	if !data.Destination.PrivateKey.IsNull() {
+		t.Error("Expected the model saved to state to have no private key")
+	}
+
+	data.Destination.Port = types.Int64Value(2222)
+	data.Destination.GZip = types.BoolValue(false)
+	if _, err := sftpExportDestination.createModel(context.Background(), client, withSftpWriteOnlyCredentials(data, credentials)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent.Destination.Port != 2222 || sent.Destination.GZip {
+		t.Errorf("Expected port 2222 without gzip, got %d and %v", sent.Destination.Port, sent.Destination.GZip)
+	}
+}
+
+func TestSftpExport_Get(t *testing.T) {
+	client := newExportTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "export-123",
+			"name": "test-export",
+			"format": {"type": "raw", "metrics": "export"},
+			"destination": {"type": "sftp", "host": "sftp.example.com", "port": 2222, "username": "funnel", "path": "/uploads/funnel", "hostKeyFingerprint": "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8", "gzip": false, "outputIdTemplate": "funnel_export_{date}", "password": "secret"}
+		}`))
+	})
+
+	export, err := getSftpExport(context.Background(), client, "test-workspace", "export-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if export.Destination.Port.ValueInt64() != 2222 || export.Destination.GZip.ValueBool() {
+		t.Errorf("Expected port and gzip from the API, got %v and %v", export.Destination.Port, export.Destination.GZip)
+	}
+	if export.Destination.Password.ValueString() != "secret" || !export.Destination.PrivateKey.IsNull() {
+		t.Errorf("Expected only the password from the API, got %v and %v", export.Destination.Password, export.Destination.PrivateKey)
+	}
+	if export.Format.Type.ValueString() != "parquet" {
+		t.Errorf("Expected format parquet, got %v", export.Format.Type)
+	}
+}
+
+func TestSftpResource_ConfigValidators_Credentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*ExportSftpDestination)
+		wantError bool
+	}{
+		{name: "write-only private key", configure: func(d *ExportSftpDestination) {}},
+		{
+			name: "password",
+			configure: func(d *ExportSftpDestination) {
+				d.PrivateKeyWO = types.StringNull()
+				d.CredentialsWOVersion = types.Int64Null()
+				d.Password = types.StringValue("password")
+			},
+		},
+		{name: "no credentials", configure: func(d *ExportSftpDestination) { d.PrivateKeyWO = types.StringNull() }, wantError: true},
+		{name: "password and private key", configure: func(d *ExportSftpDestination) { d.PasswordWO = types.StringValue("wo-password") }, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newSftpTestModel()
+			tt.configure(&data.Destination)
+
+			diags := validateResourceConfig(t, newExportDestinationResource(sftpExportDestination), data)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
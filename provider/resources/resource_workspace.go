@@ -8,6 +8,7 @@ import (
 	"terraform-provider-funnel/provider/common"
 	"terraform-provider-funnel/provider/funnel"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -24,12 +25,13 @@ func NewWorkspaceResource() resource.Resource {
 }
 
 type WorkspaceResource struct {
-	config *common.FunnelProviderModel
+	client *funnel.Client
 }
 
 type WorkspaceResourceModel struct {
-	Id   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	Id       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type FunnelWorkspaceJSON struct {
@@ -58,6 +60,9 @@ func (r *WorkspaceResource) Schema(ctx context.Context, req resource.SchemaReque
 				Required:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -66,16 +71,16 @@ func (r *WorkspaceResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	config, ok := req.ProviderData.(*common.FunnelProviderModel)
+	client, ok := req.ProviderData.(*funnel.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *FunnelProviderModel, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *funnel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.config = config
+	r.client = client
 }
 
 func (r *WorkspaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -85,13 +90,22 @@ func (r *WorkspaceResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	payload := FunnelWorkspaceJSON{
 		Name:           data.Name.ValueString(),
-		SubscriptionId: r.config.SubscriptionId.ValueString(),
+		SubscriptionId: r.client.SubscriptionId,
 	}
 
 	tflog.Info(ctx, "Creating workspace", map[string]any{"name": payload.Name})
-	respObj, apiErr := funnel.CreateSubscriptionEntity(ctx, "workspaces", r.config.SubscriptionId.ValueString(), payload, r.config)
+	respObj, apiErr := funnel.CreateSubscriptionEntity(ctx, "workspaces", r.client.SubscriptionId, payload, r.client)
 	if apiErr != nil {
 		if apiErr.StatusCode == 403 {
 			resp.Diagnostics.AddError(
@@ -122,8 +136,17 @@ func (r *WorkspaceResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "Reading workspace", map[string]any{"id": data.Id.ValueString()})
-	respObj, err := funnel.GetSubscriptionEntity[FunnelWorkspaceJSON](ctx, "workspaces", r.config.SubscriptionId.ValueString(), data.Id.ValueString(), r.config)
+	respObj, err := funnel.GetSubscriptionEntity[FunnelWorkspaceJSON](ctx, "workspaces", r.client.SubscriptionId, data.Id.ValueString(), r.client)
 	if err != nil {
 		var apiErr funnel.APIError
 		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
@@ -150,13 +173,22 @@ func (r *WorkspaceResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	payload := FunnelWorkspaceJSON{
 		Id:   data.Id.ValueString(),
 		Name: data.Name.ValueString(),
 	}
 
 	tflog.Info(ctx, "Updating workspace", map[string]any{"id": data.Id.ValueString(), "name": payload.Name})
-	_, err := funnel.UpdateSubscriptionEntity(ctx, "workspaces", r.config.SubscriptionId.ValueString(), data.Id.ValueString(), payload, r.config)
+	_, err := funnel.UpdateSubscriptionEntity(ctx, "workspaces", r.client.SubscriptionId, data.Id.ValueString(), payload, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Workspace",
@@ -175,8 +207,17 @@ func (r *WorkspaceResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "Deleting workspace", map[string]any{"id": data.Id.ValueString()})
-	err := funnel.DeleteSubscriptionEntity(ctx, "workspaces", r.config.SubscriptionId.ValueString(), data.Id.ValueString(), r.config)
+	err := funnel.DeleteSubscriptionEntity(ctx, "workspaces", r.client.SubscriptionId, data.Id.ValueString(), r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Workspace",
@@ -188,7 +229,7 @@ func (r *WorkspaceResource) Delete(ctx context.Context, req resource.DeleteReque
 
 func (r *WorkspaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Info(ctx, "Importing workspace", map[string]any{"id": req.ID})
-	respObj, err := funnel.GetSubscriptionEntity[FunnelWorkspaceJSON](ctx, "workspaces", r.config.SubscriptionId.ValueString(), req.ID, r.config)
+	respObj, err := funnel.GetSubscriptionEntity[FunnelWorkspaceJSON](ctx, "workspaces", r.client.SubscriptionId, req.ID, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Importing Workspace",
@@ -198,8 +239,9 @@ func (r *WorkspaceResource) ImportState(ctx context.Context, req resource.Import
 	}
 
 	data := WorkspaceResourceModel{
-		Id:   types.StringValue(respObj.Id),
-		Name: types.StringValue(respObj.Name),
+		Id:       types.StringValue(respObj.Id),
+		Name:     types.StringValue(respObj.Name),
+		Timeouts: common.NullTimeouts(),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -7,11 +7,10 @@ import (
 	"strings"
 	"testing"
 
-	"terraform-provider-funnel/provider/common"
+	"terraform-provider-funnel/provider/auth"
 	"terraform-provider-funnel/provider/funnel"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestWorkspaceResource_Metadata(t *testing.T) {
@@ -40,17 +39,17 @@ func TestCreateSubscriptionEntity_Workspaces_Success(t *testing.T) {
 	}))
 	defer mockServer.Close()
 
-	config := &common.FunnelProviderModel{
-		Environment: types.StringValue(mockServer.URL + "/v1"),
-		Token:       "Bearer test-token",
-	}
+	client := funnel.NewClient(funnel.ClientConfig{
+		Environment: mockServer.URL + "/v1",
+		TokenSource: auth.StaticTokenSource("test-token"),
+	})
 
 	respObj, err := funnel.CreateSubscriptionEntity[FunnelWorkspaceJSON](
 		context.Background(),
 		"workspaces",
 		"sub-123",
 		FunnelWorkspaceJSON{Name: "Workspace A"},
-		config,
+		client,
 	)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -72,17 +71,17 @@ func TestCreateSubscriptionEntity_Workspaces_Forbidden(t *testing.T) {
 	}))
 	defer mockServer.Close()
 
-	config := &common.FunnelProviderModel{
-		Environment: types.StringValue(mockServer.URL + "/v1"),
-		Token:       "Bearer test-token",
-	}
+	client := funnel.NewClient(funnel.ClientConfig{
+		Environment: mockServer.URL + "/v1",
+		TokenSource: auth.StaticTokenSource("test-token"),
+	})
 
 	_, err := funnel.CreateSubscriptionEntity[FunnelWorkspaceJSON](
 		context.Background(),
 		"workspaces",
 		"sub-123",
 		FunnelWorkspaceJSON{Name: "Workspace A"},
-		config,
+		client,
 	)
 	if err == nil {
 		t.Fatal("expected error, got nil")
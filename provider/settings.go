@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"terraform-provider-funnel/provider/auth"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// providerProfile is the credentials file profile the provider falls back to for settings
+// that are neither in the provider configuration nor in the environment.
+type providerProfile struct {
+	name   string
+	path   string
+	values auth.Profile
+}
+
+func (p providerProfile) description() string {
+	return fmt.Sprintf("profile %q in %s", p.name, p.path)
+}
+
+// loadProviderProfile loads the profile selected by the profile attribute or FUNNEL_PROFILE.
+// The default profile is optional, so a missing file or section only fails when a profile was selected.
+func loadProviderProfile(ctx context.Context, config types.String, diags *diag.Diagnostics) providerProfile {
+	profile := providerProfile{name: auth.DefaultProfile}
+	selected := true
+	switch {
+	case !config.IsNull() && !config.IsUnknown():
+		profile.name = config.ValueString()
+	case os.Getenv("FUNNEL_PROFILE") != "":
+		profile.name = os.Getenv("FUNNEL_PROFILE")
+	default:
+		selected = false
+	}
+
+	credentialsFile, err := auth.DefaultCredentialsFile()
+	if err != nil {
+		if selected {
+			diags.AddAttributeError(path.Root("profile"), "Failed to load credentials profile", err.Error())
+		}
+		return profile
+	}
+	profile.path = credentialsFile
+
+	values, err := auth.LoadProfile(credentialsFile, profile.name)
+	switch {
+	case err == nil:
+		tflog.Info(ctx, "Loaded Funnel credentials profile", map[string]any{"profile": profile.name, "path": credentialsFile})
+		profile.values = values
+	case !selected && (errors.Is(err, os.ErrNotExist) || errors.Is(err, auth.ErrProfileNotFound)):
+		tflog.Debug(ctx, "No Funnel credentials profile found", map[string]any{"profile": profile.name, "path": credentialsFile})
+	default:
+		diags.AddAttributeError(path.Root("profile"), "Failed to load credentials profile", fmt.Sprintf("Could not load %s: %v", profile.description(), err))
+	}
+
+	return profile
+}
+
+// providerSetting is a provider attribute that falls back to an environment variable and then to the credentials profile.
+type providerSetting struct {
+	attribute string
+	envVar    string
+}
+
+// resolve returns the value of the setting and a description of where it came from, or "" when no source sets it.
+func (s providerSetting) resolve(ctx context.Context, config types.String, profile providerProfile) (string, string) {
+	value, source := "", ""
+	switch {
+	case !config.IsNull() && !config.IsUnknown():
+		value, source = config.ValueString(), "provider configuration"
+	case os.Getenv(s.envVar) != "":
+		value, source = os.Getenv(s.envVar), "environment variable "+s.envVar
+	case profile.values[s.attribute] != "":
+		value, source = profile.values[s.attribute], profile.description()
+	}
+
+	if source != "" {
+		tflog.Info(ctx, "Resolved provider setting", map[string]any{"attribute": s.attribute, "source": source})
+	}
+
+	return value, source
+}
+
+// missingSettingsError describes which required settings no source sets and where they can be set.
+func missingSettingsError(missing []providerSetting, profile providerProfile) string {
+	attributes := make([]string, len(missing))
+	envVars := make([]string, len(missing))
+	for i, setting := range missing {
+		attributes[i] = setting.attribute
+		envVars[i] = setting.envVar
+	}
+
+	profileSource := "a profile in ~/.funnel/credentials"
+	if profile.path != "" {
+		profileSource = profile.description()
+		if profile.values == nil {
+			profileSource += " (not found)"
+		}
+	}
+
+	return fmt.Sprintf(
+		"No value found for %s. Set them in the provider configuration, with the environment variables %s, or in %s.",
+		strings.Join(attributes, ", "),
+		strings.Join(envVars, ", "),
+		profileSource,
+	)
+}
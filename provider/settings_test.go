@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func useCredentialsFile(t *testing.T, content string) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("FUNNEL_PROFILE", "")
+	if content == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Join(home, ".funnel"), 0o700); err != nil {
+		t.Fatalf("could not create credentials directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".funnel", "credentials"), []byte(content), 0o600); err != nil {
+		t.Fatalf("could not write credentials file: %v", err)
+	}
+}
+
+func TestProviderSetting_Precedence(t *testing.T) {
+	useCredentialsFile(t, "[default]\nclient_id = profile-id\n")
+
+	var diags diag.Diagnostics
+	profile := loadProviderProfile(context.Background(), types.StringNull(), &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	t.Setenv("FUNNEL_CLIENT_ID", "")
+	value, source := clientIdSetting.resolve(context.Background(), types.StringNull(), profile)
+	if value != "profile-id" || !strings.HasPrefix(source, `profile "default"`) {
+		t.Errorf("expected the profile value, got %q from %q", value, source)
+	}
+
+	t.Setenv("FUNNEL_CLIENT_ID", "env-id")
+	value, source = clientIdSetting.resolve(context.Background(), types.StringNull(), profile)
+	if value != "env-id" || source != "environment variable FUNNEL_CLIENT_ID" {
+		t.Errorf("expected the environment value, got %q from %q", value, source)
+	}
+
+	value, source = clientIdSetting.resolve(context.Background(), types.StringValue("config-id"), profile)
+	if value != "config-id" || source != "provider configuration" {
+		t.Errorf("expected the configured value, got %q from %q", value, source)
+	}
+}
+
+func TestLoadProviderProfile_DefaultProfileIsOptional(t *testing.T) {
+	useCredentialsFile(t, "")
+
+	var diags diag.Diagnostics
+	profile := loadProviderProfile(context.Background(), types.StringNull(), &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if profile.values != nil {
+		t.Errorf("expected no profile values, got %v", profile.values)
+	}
+}
+
+func TestLoadProviderProfile_SelectedProfileMustExist(t *testing.T) {
+	useCredentialsFile(t, "[default]\nclient_id = profile-id\n")
+	t.Setenv("FUNNEL_PROFILE", "ci")
+
+	var diags diag.Diagnostics
+	loadProviderProfile(context.Background(), types.StringNull(), &diags)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a missing profile")
+	}
+}
+
+func TestMissingSettingsError_ListsAttributesAndSources(t *testing.T) {
+	profile := providerProfile{name: "ci", path: "/home/user/.funnel/credentials"}
+
+	detail := missingSettingsError([]providerSetting{clientIdSetting, clientSecretSetting}, profile)
+	for _, expected := range []string{"client_id, client_secret", "FUNNEL_CLIENT_ID, FUNNEL_CLIENT_SECRET", `profile "ci" in /home/user/.funnel/credentials (not found)`} {
+		if !strings.Contains(detail, expected) {
+			t.Errorf("expected %q in %q", expected, detail)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// cronField describes one of the five fields of a cron expression.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	// Both 0 and 7 are Sunday.
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// ParseCron checks a standard five field cron expression: minute, hour, day of month, month and day of week.
+// Every field is a comma separated list of *, a value or a range a-b, each optionally with a step /n.
+// Months and days of week can also be given by their three letter English names.
+func ParseCron(expression string) error {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		if strings.HasPrefix(strings.TrimSpace(expression), "@") {
+			return fmt.Errorf("macros like %q are not supported, use five fields: minute hour day-of-month month day-of-week", strings.TrimSpace(expression))
+		}
+		return fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+
+	for i, field := range fields {
+		if err := cronFields[i].parse(field); err != nil {
+			return fmt.Errorf("invalid %s field %q: %w", cronFields[i].name, field, err)
+		}
+	}
+
+	return nil
+}
+
+func (f cronField) parse(field string) error {
+	for _, item := range strings.Split(field, ",") {
+		if item == "" {
+			return fmt.Errorf("empty list item")
+		}
+
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			step, err := strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return fmt.Errorf("step %q must be a positive number", stepPart)
+			}
+			if step > f.max-f.min+1 {
+				return fmt.Errorf("step %d is larger than the range %d-%d", step, f.min, f.max)
+			}
+		}
+
+		if rangePart == "*" {
+			continue
+		}
+
+		startPart, endPart, isRange := strings.Cut(rangePart, "-")
+		start, err := f.value(startPart)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+
+		end, err := f.value(endPart)
+		if err != nil {
+			return err
+		}
+		if start > end {
+			return fmt.Errorf("range %s-%s starts after it ends", startPart, endPart)
+		}
+	}
+
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		if f.names != nil {
+			return 0, fmt.Errorf("%q is not a number or a three letter name", s)
+		}
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is out of range %d-%d", n, f.min, f.max)
+	}
+
+	return n, nil
+}
+
+type cronValidator struct{}
+
+// Cron returns a validator that checks that a string is a valid five field cron expression.
+func Cron() validator.String {
+	return cronValidator{}
+}
+
+func (v cronValidator) Description(ctx context.Context) string {
+	return "value must be a five field cron expression: minute hour day-of-month month day-of-week"
+}
+
+func (v cronValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a five field cron expression: `minute hour day-of-month month day-of-week`"
+}
+
+func (v cronValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := ParseCron(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Cron Expression",
+			fmt.Sprintf("The schedule %q is not a valid cron expression: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
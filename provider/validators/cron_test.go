@@ -0,0 +1,64 @@
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expression  string
+		errContains string
+	}{
+		{expression: "0 4 * * *"},
+		{expression: "*/15 * * * *"},
+		{expression: "0 6-18/2 * * mon-fri"},
+		{expression: "30 1 1,15 JAN,jul *"},
+		{expression: "0 0 * * 7"},
+		{expression: "0 4 * *", errContains: "expected 5 fields"},
+		{expression: "@daily", errContains: "macros"},
+		{expression: "60 4 * * *", errContains: "invalid minute field \"60\": 60 is out of range 0-59"},
+		{expression: "0 24 * * *", errContains: "invalid hour field"},
+		{expression: "0 4 0 * *", errContains: "invalid day of month field"},
+		{expression: "0 4 * 13 *", errContains: "invalid month field"},
+		{expression: "0 4 * * funday", errContains: "not a number or a three letter name"},
+		{expression: "0 18-6 * * *", errContains: "starts after it ends"},
+		{expression: "*/0 * * * *", errContains: "must be a positive number"},
+		{expression: "0 4,,5 * * *", errContains: "empty list item"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			err := ParseCron(tt.expression)
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("expected an error containing %q, got %v", tt.errContains, err)
+			}
+		})
+	}
+}
+
+func TestCronValidator(t *testing.T) {
+	for value, wantError := range map[types.String]bool{
+		types.StringValue("0 4 * * *"): false,
+		types.StringValue("every day"): true,
+		types.StringNull():             false,
+		types.StringUnknown():          false,
+	} {
+		resp := &validator.StringResponse{}
+		Cron().ValidateString(context.Background(), validator.StringRequest{Path: path.Root("schedule"), ConfigValue: value}, resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("%s: expected error %v, got %v", value, wantError, resp.Diagnostics)
+		}
+	}
+}
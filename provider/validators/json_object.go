@@ -0,0 +1,38 @@
+package validators
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type jsonObjectValidator struct{}
+
+// JSONObject returns a validator that checks that a string is a JSON object, like the output of jsonencode for a map.
+func JSONObject() validator.String {
+	return jsonObjectValidator{}
+}
+
+func (v jsonObjectValidator) Description(ctx context.Context) string {
+	return "value must be a JSON object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a JSON object, e.g. from `jsonencode`"
+}
+
+func (v jsonObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &object); err != nil || object == nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Object",
+			"The value must be a JSON object like {\"=and\": [...]}, e.g. from jsonencode.",
+		)
+	}
+}
@@ -0,0 +1,29 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestJSONObjectValidator(t *testing.T) {
+	for value, wantError := range map[types.String]bool{
+		types.StringValue(`{"=and": [{"channel": {"=eq": "Google Ads"}}]}`): false,
+		types.StringValue(`{}`):        false,
+		types.StringValue(`["=and"]`):  true,
+		types.StringValue(`null`):      true,
+		types.StringValue(`{"=and": `): true,
+		types.StringValue(""):          true,
+		types.StringNull():             false,
+		types.StringUnknown():          false,
+	} {
+		resp := &validator.StringResponse{}
+		JSONObject().ValidateString(context.Background(), validator.StringRequest{Path: path.Root("where_json"), ConfigValue: value}, resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("%s: expected error %v, got %v", value, wantError, resp.Diagnostics)
+		}
+	}
+}
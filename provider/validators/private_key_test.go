@@ -0,0 +1,86 @@
+package validators
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testPrivateKeyPEM(t *testing.T) string {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	key := testPrivateKeyPEM(t)
+	encryptedKey := string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("encrypted")}))
+
+	tests := []struct {
+		name          string
+		key           string
+		wantEncrypted bool
+		errContains   string
+	}{
+		{name: "unencrypted", key: key},
+		{name: "surrounding whitespace", key: "\n  " + key + "\n"},
+		{name: "encrypted", key: encryptedKey, wantEncrypted: true},
+		{name: "not pem", key: "MIIEvQIBADANBgkqhkiG9w0BAQEFAASC", errContains: "expected a PEM block"},
+		{name: "two keys", key: key + key, errContains: "unexpected data after"},
+		{name: "invalid key", key: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})), errContains: "not a valid PKCS#8 private key"},
+		{name: "pkcs1", key: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte("key")})), errContains: "openssl pkcs8 -topk8"},
+		{name: "public key", key: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("key")})), errContains: "is not a private key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encrypted, err := ParsePrivateKeyPEM(tt.key)
+			if tt.errContains == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if encrypted != tt.wantEncrypted {
+					t.Errorf("expected encrypted %v, got %v", tt.wantEncrypted, encrypted)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("expected error containing %q, got %v", tt.errContains, err)
+			}
+		})
+	}
+}
+
+func TestPrivateKeyPEMValidator(t *testing.T) {
+	for value, wantError := range map[types.String]bool{
+		types.StringValue(testPrivateKeyPEM(t)): false,
+		types.StringValue("not a key"):          true,
+		types.StringNull():                      false,
+		types.StringUnknown():                   false,
+	} {
+		resp := &validator.StringResponse{}
+		PrivateKeyPEM().ValidateString(context.Background(), validator.StringRequest{Path: path.Root("private_key"), ConfigValue: value}, resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("%s: expected error %v, got %v", value, wantError, resp.Diagnostics)
+		}
+		if strings.Contains(fmt.Sprint(resp.Diagnostics), "not a key") {
+			t.Errorf("expected the key to be left out of the message, got %v", resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+	// Embed the time zone database so time zones validate without one on the host.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// CheckFixedOffset returns an error when the offset of the time zone changes during the year of now, like it does with
+// daylight saving time.
+func CheckFixedOffset(location *time.Location, now time.Time) error {
+	year := now.In(location).Year()
+	_, january := time.Date(year, time.January, 1, 0, 0, 0, 0, location).Zone()
+	_, july := time.Date(year, time.July, 1, 0, 0, 0, 0, location).Zone()
+	if january != july {
+		return fmt.Errorf("%s has daylight saving time, use a time zone with a fixed offset like UTC or Etc/GMT-1", location)
+	}
+	return nil
+}
+
+type timeZoneValidator struct{}
+
+// TimeZone returns a validator that checks that a string is an IANA time zone name with a fixed offset like UTC.
+// Time zones with daylight saving time are not allowed.
+func TimeZone() validator.String {
+	return timeZoneValidator{}
+}
+
+func (v timeZoneValidator) Description(ctx context.Context) string {
+	return "value must be an IANA time zone name with a fixed offset like UTC or Etc/GMT-1, time zones with daylight saving time are not allowed"
+}
+
+func (v timeZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an IANA time zone name with a fixed offset like `UTC` or `Etc/GMT-1`, time zones with daylight saving time are not allowed"
+}
+
+func (v timeZoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	location, err := time.LoadLocation(req.ConfigValue.ValueString())
+	if err != nil || req.ConfigValue.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Zone",
+			fmt.Sprintf("%q is not an IANA time zone name like \"UTC\" or \"Etc/GMT-1\".", req.ConfigValue.ValueString()),
+		)
+		return
+	}
+
+	if err := CheckFixedOffset(location, time.Now()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Time Zone", err.Error()+".")
+	}
+}
@@ -0,0 +1,28 @@
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimeZoneValidator(t *testing.T) {
+	for value, wantError := range map[types.String]bool{
+		types.StringValue("Europe/Stockholm"): true,
+		types.StringValue("Asia/Tokyo"):       false,
+		types.StringValue("Etc/GMT-1"):        false,
+		types.StringValue("UTC"):              false,
+		types.StringValue("Mars/Olympus"):     true,
+		types.StringValue(""):                 true,
+		types.StringNull():                    false,
+	} {
+		resp := &validator.StringResponse{}
+		TimeZone().ValidateString(context.Background(), validator.StringRequest{Path: path.Root("timezone"), ConfigValue: value}, resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("%s: expected error %v, got %v", value, wantError, resp.Diagnostics)
+		}
+	}
+}